@@ -0,0 +1,7 @@
+data "ironic_conductor" "conductor" {
+  hostname = "ironic-conductor-0"
+}
+
+output "conductor_drivers" {
+  value = data.ironic_conductor.conductor.alive ? data.ironic_conductor.conductor.drivers : []
+}
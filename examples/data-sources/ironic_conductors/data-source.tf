@@ -0,0 +1,12 @@
+data "ironic_conductors" "redfish" {
+  alive  = true
+  driver = "redfish"
+}
+
+# The conductor groups able to manage redfish nodes
+output "redfish_conductor_groups" {
+  value = distinct([
+    for conductor in data.ironic_conductors.redfish.conductors :
+    conductor.conductor_group
+  ])
+}
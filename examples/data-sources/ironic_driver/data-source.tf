@@ -0,0 +1,22 @@
+variable "driver_info" {
+  type = map(string)
+}
+
+data "ironic_driver" "redfish" {
+  name = "redfish"
+}
+
+# Check a node's driver_info against the properties the driver requires
+check "driver_info" {
+  assert {
+    condition = length(setsubtract(
+      data.ironic_driver.redfish.required_properties,
+      keys(var.driver_info),
+    )) == 0
+    error_message = "driver_info misses required properties of the redfish driver."
+  }
+}
+
+output "redfish_boot_interfaces" {
+  value = data.ironic_driver.redfish.enabled_interfaces["boot"]
+}
@@ -0,0 +1,10 @@
+data "ironic_drivers" "hardware_types" {
+  type = "dynamic"
+}
+
+output "default_boot_interfaces" {
+  value = {
+    for driver in data.ironic_drivers.hardware_types.drivers :
+    driver.name => driver.default_interfaces["boot"]
+  }
+}
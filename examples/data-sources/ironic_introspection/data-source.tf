@@ -0,0 +1,21 @@
+provider "ironic" {
+  url       = "http://localhost:6385/v1"
+  inspector = "http://localhost:5050/v1"
+}
+
+data "ironic_introspection" "default-master-0" {
+  uuid = ironic_node.default-master-0.id
+}
+
+output "introspection" {
+  value = {
+    finished  = data.ironic_introspection.default-master-0.finished
+    cpu_arch  = data.ironic_introspection.default-master-0.cpu_arch
+    cpu_count = data.ironic_introspection.default-master-0.cpu_count
+    memory_mb = data.ironic_introspection.default-master-0.memory_mb
+  }
+}
+
+output "pxe_macs" {
+  value = [for iface in data.ironic_introspection.default-master-0.interfaces : iface.mac]
+}
@@ -0,0 +1,18 @@
+# Look up a node enrolled by metal3, named namespace~name
+data "ironic_node" "worker-0" {
+  namespace = "metal3"
+  name      = "worker-0"
+}
+
+# Look up the node a port belongs to
+data "ironic_node" "by_mac" {
+  mac_address = "52:54:00:cf:2d:31"
+}
+
+output "worker_0" {
+  value = {
+    uuid            = data.ironic_node.worker-0.uuid
+    provision_state = data.ironic_node.worker-0.provision_state
+    ports           = [for port in data.ironic_node.worker-0.ports : port.mac_address]
+  }
+}
@@ -0,0 +1,12 @@
+# Available GPU workers of a conductor group
+data "ironic_nodes" "gpu_workers" {
+  provision_state = "available"
+  conductor_group = "rack-1"
+  maintenance     = false
+  name_prefix     = "worker-"
+  traits          = ["CUSTOM_GPU"]
+}
+
+output "gpu_workers" {
+  value = [for node in data.ironic_nodes.gpu_workers.nodes : node.uuid]
+}
@@ -0,0 +1,7 @@
+data "ironic_port" "provisioning" {
+  address = "52:54:00:cf:2d:31"
+}
+
+output "switch_port" {
+  value = data.ironic_port.provisioning.local_link_connection
+}
@@ -0,0 +1,7 @@
+data "ironic_port_group" "bond0" {
+  name = "bond0"
+}
+
+output "bond_mode" {
+  value = data.ironic_port_group.bond0.mode
+}
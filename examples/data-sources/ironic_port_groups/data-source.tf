@@ -0,0 +1,7 @@
+data "ironic_port_groups" "worker-0" {
+  node_uuid = data.ironic_node.worker-0.uuid
+}
+
+output "bonds" {
+  value = { for group in data.ironic_port_groups.worker-0.port_groups : group.name => group.mode }
+}
@@ -0,0 +1,12 @@
+# The ports of a node connected to the provisioning network
+data "ironic_ports" "provisioning" {
+  node_uuid        = data.ironic_node.worker-0.uuid
+  physical_network = "provisioning"
+}
+
+output "switch_ports" {
+  value = {
+    for port in data.ironic_ports.provisioning.ports :
+    port.address => port.local_link_connection if port.pxe_enabled
+  }
+}
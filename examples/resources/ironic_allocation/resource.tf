@@ -47,3 +47,9 @@ resource "ironic_allocation_v1" "cluster_allocation" {
     index = tostring(count.index)
   }
 }
+
+# Backfill an allocation for a node that is already deployed
+resource "ironic_allocation_v1" "backfilled_allocation" {
+  name = "existing-worker"
+  node = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+}
@@ -0,0 +1,30 @@
+provider "ironic" {
+  url       = "http://localhost:6385/v1"
+  inspector = "http://localhost:5050/v1"
+}
+
+# Set the deploy images on every node discovered with enough memory
+resource "ironic_introspection_rule" "deploy_images" {
+  description = "Set deploy images"
+
+  conditions = [
+    {
+      op    = "ge"
+      field = "memory_mb"
+      value = 4096
+    },
+  ]
+
+  actions = [
+    {
+      action = "set-attribute"
+      path   = "/driver_info/deploy_kernel"
+      value  = "http://172.22.0.1/images/ironic-python-agent.kernel"
+    },
+    {
+      action = "set-attribute"
+      path   = "/driver_info/deploy_ramdisk"
+      value  = "http://172.22.0.1/images/ironic-python-agent.initramfs"
+    },
+  ]
+}
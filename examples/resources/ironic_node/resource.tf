@@ -5,6 +5,10 @@ resource "ironic_node" "openshift-master-0" {
   clean     = true # Clean the node
   available = true # Make the node 'available'
 
+  clean_steps = [
+    { interface = "deploy", step = "erase_devices_metadata" },
+  ]
+
   ports = [
     {
       "address"     = "00:bb:4a:d0:5e:38"
@@ -17,6 +21,8 @@ resource "ironic_node" "openshift-master-0" {
     "cpu_arch" = "x86_64"
   }
 
+  traits = ["CUSTOM_GPU"] # Matched by allocations
+
   driver = "ipmi"
   driver_info = {
     "ipmi_port"      = "6230"
@@ -0,0 +1,13 @@
+# Apply BIOS settings through manual cleaning
+resource "ironic_node_bios_settings" "openshift-master-0" {
+  node_uuid = ironic_node.openshift-master-0.id
+
+  settings = {
+    "BootMode"      = "Uefi"
+    "ProcTurboMode" = "Disabled"
+  }
+
+  timeouts {
+    update = "1h"
+  }
+}
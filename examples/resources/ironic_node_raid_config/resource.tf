@@ -0,0 +1,23 @@
+# Mirror two disks for the root volume, and use the rest as scratch space
+resource "ironic_node_raid_config" "openshift-master-0" {
+  node_uuid = ironic_node.openshift-master-0.id
+
+  logical_disks = [
+    {
+      size_gb        = 100
+      raid_level     = "1"
+      is_root_volume = true
+      controller     = "RAID.Integrated.1-1"
+      physical_disks = ["Disk.Bay.0:Enclosure.Internal.0-1", "Disk.Bay.1:Enclosure.Internal.0-1"]
+      volume_name    = "root"
+    },
+    {
+      raid_level = "0"
+    },
+  ]
+
+  timeouts {
+    create = "1h"
+    update = "1h"
+  }
+}
@@ -0,0 +1,23 @@
+# Update the BMC firmware of a deployed node without redeploying it
+resource "ironic_node_service" "openshift-master-0" {
+  node_uuid = ironic_deployment.openshift-master-0.node_uuid
+
+  service_steps = [
+    {
+      interface = "firmware"
+      step      = "update"
+      args = {
+        settings = jsonencode([
+          { component = "bmc", url = "http://172.22.0.1/firmware/bmc-1.2.3.bin" },
+        ])
+      }
+    },
+  ]
+
+  disable_ramdisk = true
+
+  # Run the steps again when the firmware version changes
+  triggers = {
+    bmc_version = "1.2.3"
+  }
+}
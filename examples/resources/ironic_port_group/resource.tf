@@ -35,10 +35,16 @@ resource "ironic_port_group" "advanced" {
   mode      = "802.3ad"
   address   = "aa:bb:cc:dd:ee:ff"
 
+  standalone_ports_supported = false
+  physical_network           = "provisioning"
+
+  properties = {
+    miimon           = "100"
+    xmit_hash_policy = "layer3+4"
+  }
+
   extra = {
-    bond_mode   = "LACP"
     lacp_rate   = "fast"
-    mii_mon     = "100"
     environment = "production"
     created_by  = "terraform"
   }
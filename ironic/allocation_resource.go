@@ -2,12 +2,15 @@ package ironic
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/gophercloud/gophercloud/v2"
 	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/allocations"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -15,7 +18,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/dynamicplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -30,8 +32,24 @@ var (
 	_ resource.Resource                = &allocationV1Resource{}
 	_ resource.ResourceWithConfigure   = &allocationV1Resource{}
 	_ resource.ResourceWithImportState = &allocationV1Resource{}
+	_ resource.ResourceWithModifyPlan  = &allocationV1Resource{}
 )
 
+// allocationMicroversions lists the microversions needed by allocations, the
+// API itself first appeared in 1.52.
+var allocationMicroversions = []microversionRequirement{
+	{Version: "1.52"},
+	{Attribute: "node", Version: "1.58"},
+}
+
+// allocationUpdateMicroversion is needed to update the name and extra of an
+// allocation.
+const allocationUpdateMicroversion = "1.57"
+
+// defaultAllocationTimeout is how long to wait for an allocation to be
+// processed when no create timeout is configured.
+const defaultAllocationTimeout = time.Minute
+
 // allocationV1Resource defines the resource implementation.
 type allocationV1Resource struct {
 	meta *Meta
@@ -39,16 +57,17 @@ type allocationV1Resource struct {
 
 // allocationV1ResourceModel describes the resource data model.
 type allocationV1ResourceModel struct {
-	ID             types.String  `tfsdk:"id"`
-	Name           types.String  `tfsdk:"name"`
-	ResourceClass  types.String  `tfsdk:"resource_class"`
-	CandidateNodes types.List    `tfsdk:"candidate_nodes"`
-	Node           types.String  `tfsdk:"node"`
-	Traits         types.List    `tfsdk:"traits"`
-	Extra          types.Dynamic `tfsdk:"extra"`
-	NodeUUID       types.String  `tfsdk:"node_uuid"`
-	State          types.String  `tfsdk:"state"`
-	LastError      types.String  `tfsdk:"last_error"`
+	ID             types.String   `tfsdk:"id"`
+	Name           types.String   `tfsdk:"name"`
+	ResourceClass  types.String   `tfsdk:"resource_class"`
+	CandidateNodes types.List     `tfsdk:"candidate_nodes"`
+	Node           types.String   `tfsdk:"node"`
+	Traits         types.List     `tfsdk:"traits"`
+	Extra          types.Dynamic  `tfsdk:"extra"`
+	NodeUUID       types.String   `tfsdk:"node_uuid"`
+	State          types.String   `tfsdk:"state"`
+	LastError      types.String   `tfsdk:"last_error"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
 }
 
 func NewAllocationV1Resource() resource.Resource {
@@ -74,14 +93,22 @@ func (r *allocationV1Resource) Schema(
 			"id": schema.StringAttribute{
 				MarkdownDescription: "The UUID of the allocation.",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"name": schema.StringAttribute{
 				MarkdownDescription: "The name of the allocation.",
 				Optional:            true,
 			},
 			"resource_class": schema.StringAttribute{
-				MarkdownDescription: "The resource class required for this allocation.",
-				Required:            true,
+				MarkdownDescription: "The resource class required for this allocation. Required unless `node` is set, a backfilled allocation takes the resource class of its node.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"candidate_nodes": schema.ListAttribute{
 				MarkdownDescription: "List of candidate node UUIDs for this allocation.",
@@ -92,18 +119,19 @@ func (r *allocationV1Resource) Schema(
 					listvalidator.ConflictsWith(path.MatchRoot("node")),
 				},
 				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
 					listplanmodifier.RequiresReplace(),
 				},
 			},
 			"node": schema.StringAttribute{
-				MarkdownDescription: `Backfill this allocation from the provided node that has already been deployed. 
-				Bypasses the normal allocation process. Conflicts with "candidate_nodes".`,
-				Optional: true,
-				Computed: true,
+				MarkdownDescription: "Backfill this allocation from the provided node that has already been deployed, bypassing the normal allocation process. Conflicts with `candidate_nodes`.",
+				Optional:            true,
+				Computed:            true,
 				Validators: []validator.String{
 					stringvalidator.ConflictsWith(path.MatchRoot("candidate_nodes")),
 				},
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -118,23 +146,32 @@ func (r *allocationV1Resource) Schema(
 			"extra": schema.DynamicAttribute{
 				MarkdownDescription: "Extra metadata for the allocation.",
 				Optional:            true,
-				PlanModifiers: []planmodifier.Dynamic{
-					dynamicplanmodifier.RequiresReplace(),
-				},
 			},
 			"node_uuid": schema.StringAttribute{
 				MarkdownDescription: "The UUID of the node allocated to this allocation.",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"state": schema.StringAttribute{
 				MarkdownDescription: "The current state of the allocation.",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"last_error": schema.StringAttribute{
 				MarkdownDescription: "The last error message for the allocation.",
 				Computed:            true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -162,6 +199,53 @@ func (r *allocationV1Resource) Configure(
 	r.meta = clients
 }
 
+// ModifyPlan reports attributes that need a newer Ironic API microversion
+// than the provider is using.
+func (r *allocationV1Resource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	// Nothing to check on destroy, or before the provider is configured.
+	if req.Plan.Raw.IsNull() || r.meta == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(
+		r.meta.validateMicroversions(ctx, "ironic_allocation", req.Config, allocationMicroversions)...,
+	)
+
+	var resourceClass, node types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("resource_class"), &resourceClass)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("node"), &node)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if resourceClass.IsNull() && node.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("resource_class"),
+			"Missing Resource Class",
+			"resource_class must be set unless node is given to backfill the allocation.",
+		)
+	}
+
+	// Renaming or changing extra patches the allocation
+	if req.State.Raw.IsNull() {
+		return
+	}
+	var plan, state allocationV1ResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !plan.Name.Equal(state.Name) || !plan.Extra.Equal(state.Extra) {
+		if d := r.meta.checkMicroversion("Updating ironic_allocation", allocationUpdateMicroversion); d != nil {
+			resp.Diagnostics.Append(d)
+		}
+	}
+}
+
 func (r *allocationV1Resource) Create(
 	ctx context.Context,
 	req resource.CreateRequest,
@@ -176,15 +260,26 @@ func (r *allocationV1Resource) Create(
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultAllocationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	// Prepare create options
-	createOpts := allocations.CreateOpts{}
+	createOpts := allocationCreateOpts{}
 
 	// Set optional fields
 	if !plan.Name.IsNull() && !plan.Name.IsUnknown() {
 		createOpts.Name = plan.Name.ValueString()
 	}
 
-	createOpts.ResourceClass = plan.ResourceClass.ValueString()
+	if !plan.ResourceClass.IsNull() && !plan.ResourceClass.IsUnknown() {
+		createOpts.ResourceClass = plan.ResourceClass.ValueString()
+	}
 
 	// Handle candidate nodes list
 	if !plan.CandidateNodes.IsNull() && !plan.CandidateNodes.IsUnknown() {
@@ -198,41 +293,8 @@ func (r *allocationV1Resource) Create(
 	}
 
 	if !plan.Node.IsNull() && !plan.Node.IsUnknown() {
-		// If a node is specified, it must be a single UUID
-		if plan.CandidateNodes.IsNull() || plan.CandidateNodes.IsUnknown() ||
-			len(plan.CandidateNodes.Elements()) == 0 {
-			backfillOpts := struct {
-				*allocations.CreateOpts
-				Node string `json:"node"`
-			}{
-				&createOpts,
-				plan.Node.ValueString(),
-			}
-			reqBody, err := gophercloud.BuildRequestBody(backfillOpts, "")
-			if err != nil {
-				resp.Diagnostics.AddAttributeError(
-					path.Root("node"),
-					"Error Building Request Body",
-					fmt.Sprintf("Could not build request body for node backfill: %s", err),
-				)
-				return
-			}
-			res, err := r.meta.Client.Post(
-				ctx,
-				r.meta.Client.ServiceURL("allocations"),
-				reqBody,
-				&reqBody,
-				nil,
-			)
-			_, _, err = gophercloud.ParseResponse(res, err)
-			if err != nil {
-				resp.Diagnostics.AddError(
-					"Error creating allocation with node backfill",
-					fmt.Sprintf("Could not create allocation with node backfill: %s", err),
-				)
-				return
-			}
-		} else {
+		// Backfill the allocation of an already deployed node
+		if len(createOpts.CandidateNodes) > 0 {
 			resp.Diagnostics.AddAttributeError(
 				path.Root("node"),
 				"Node Conflicts with Candidate Nodes",
@@ -240,6 +302,7 @@ func (r *allocationV1Resource) Create(
 			)
 			return
 		}
+		createOpts.Node = plan.Node.ValueString()
 	} else if !plan.CandidateNodes.IsNull() && !plan.CandidateNodes.IsUnknown() {
 		// If candidate nodes are specified, ensure they are not empty
 		if len(plan.CandidateNodes.Elements()) == 0 {
@@ -344,11 +407,69 @@ func (r *allocationV1Resource) Update(
 	req resource.UpdateRequest,
 	resp *resource.UpdateResponse,
 ) {
-	// Allocations do not support updates - they are immutable
-	resp.Diagnostics.AddError(
-		"Update Not Supported",
-		"Allocations are immutable and cannot be updated. Any changes require replacement.",
-	)
+	var plan, state allocationV1ResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the name and extra can change in place
+	updateOpts := nodes.UpdateOpts{}
+
+	if !plan.Name.Equal(state.Name) {
+		if plan.Name.IsNull() || plan.Name.ValueString() == "" {
+			updateOpts = append(updateOpts, nodes.UpdateOperation{
+				Op:   nodes.RemoveOp,
+				Path: "/name",
+			})
+		} else {
+			updateOpts = append(updateOpts, nodes.UpdateOperation{
+				Op:    nodes.ReplaceOp,
+				Path:  "/name",
+				Value: plan.Name.ValueString(),
+			})
+		}
+	}
+
+	if !plan.Extra.Equal(state.Extra) {
+		if plan.Extra.IsNull() {
+			updateOpts = append(updateOpts, nodes.UpdateOperation{
+				Op:    nodes.ReplaceOp,
+				Path:  "/extra",
+				Value: map[string]string{},
+			})
+		} else {
+			util.AddDynamicUpdateOpsForField(
+				ctx, &updateOpts, &resp.Diagnostics, plan.Extra, state.Extra, "extra",
+			)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+	}
+
+	if len(updateOpts) > 0 {
+		err := patchResource(ctx, r.meta.Client, updateOpts, "allocations", state.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating allocation",
+				fmt.Sprintf("Could not update allocation %s: %s", state.ID.ValueString(), err),
+			)
+			return
+		}
+
+		resp.Diagnostics.Append(r.readAllocationData(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		// Keep the configured name when Ironic reports it as empty
+		state.Name = plan.Name
+	}
+
+	state.Timeouts = plan.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 }
 
 func (r *allocationV1Resource) Delete(
@@ -365,6 +486,15 @@ func (r *allocationV1Resource) Delete(
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultAllocationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Check if allocation exists before trying to delete
 	_, err := allocations.Get(ctx, r.meta.Client, state.ID.ValueString()).Extract()
 	if err != nil {
@@ -399,13 +529,16 @@ func (r *allocationV1Resource) ImportState(
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
-// Helper function to wait for allocation completion.
+// Helper function to wait for allocation completion, for at most one minute
+// unless the context has an earlier deadline.
 func (r *allocationV1Resource) waitForAllocationComplete(
 	ctx context.Context,
 	allocationID string,
 	model *allocationV1ResourceModel,
 ) (diagnostics diag.Diagnostics) {
-	timeout := 1 * time.Minute
+	ctx, cancel, timeout := withDefaultTimeout(ctx, defaultAllocationTimeout)
+	defer cancel()
+
 	checkInterval := 2 * time.Second
 
 	for {
@@ -423,13 +556,16 @@ func (r *allocationV1Resource) waitForAllocationComplete(
 
 		switch state {
 		case "allocating":
-			time.Sleep(checkInterval)
-			checkInterval += 2 * time.Second
-			timeout -= checkInterval
-			if timeout < 0 {
-				diagnostics.AddError("timed out waiting for allocation", "timeout exceeded")
+			select {
+			case <-ctx.Done():
+				diagnostics.AddError(
+					"timed out waiting for allocation",
+					fmt.Sprintf("allocation %s is still allocating after %v", allocationID, timeout),
+				)
 				return diagnostics
+			case <-time.After(checkInterval):
 			}
+			checkInterval += 2 * time.Second
 		case "error":
 			errorMsg := model.LastError.ValueString()
 			diagnostics.AddError("error creating allocation", errorMsg)
@@ -524,3 +660,34 @@ func (r *allocationV1Resource) readAllocationData(
 	}
 	return diagnostics
 }
+
+// allocationCreateOpts adds backfilling to the gophercloud create options,
+// which always require a resource class.
+type allocationCreateOpts struct {
+	allocations.CreateOpts
+	// Node backfills the allocation of a deployed node, it requires
+	// microversion 1.58.
+	Node string
+}
+
+// ToAllocationCreateMap assembles the request body.
+func (opts allocationCreateOpts) ToAllocationCreateMap() (map[string]any, error) {
+	if opts.Node == "" {
+		return opts.CreateOpts.ToAllocationCreateMap()
+	}
+
+	// A backfilled allocation takes the resource class of its node
+	b, err := json.Marshal(opts.CreateOpts)
+	if err != nil {
+		return nil, err
+	}
+	var body map[string]any
+	if err := json.Unmarshal(b, &body); err != nil {
+		return nil, err
+	}
+	if opts.ResourceClass == "" {
+		delete(body, "resource_class")
+	}
+	body["node"] = opts.Node
+	return body, nil
+}
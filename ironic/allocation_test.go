@@ -0,0 +1,365 @@
+package ironic
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/noauth"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/allocations"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	th "github.com/metal3-community/terraform-provider-ironic/testhelper"
+)
+
+// fakeAllocationIronic serves a single allocation, and records the create
+// requests and patches made to it.
+type fakeAllocationIronic struct {
+	mu         sync.Mutex
+	allocation map[string]any
+	creates    []map[string]any
+	patches    [][]map[string]any
+}
+
+func (f *fakeAllocationIronic) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	switch r.Method + " " + r.URL.Path {
+	case "POST /v1/allocations":
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.creates = append(f.creates, body)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(f.allocation)
+	case "GET /v1/allocations/alloc":
+		_ = json.NewEncoder(w).Encode(f.allocation)
+	case "PATCH /v1/allocations/alloc":
+		var patch []map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&patch)
+		f.patches = append(f.patches, patch)
+		_ = json.NewEncoder(w).Encode(f.allocation)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// newAllocationTestResource returns an allocation resource talking to fake,
+// and its schema.
+func newAllocationTestResource(t *testing.T, fake *fakeAllocationIronic) (*allocationV1Resource, resource.SchemaResponse) {
+	t.Helper()
+
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	client, err := noauth.NewBareMetalNoAuth(noauth.EndpointOpts{
+		IronicEndpoint: server.URL + "/v1",
+	})
+	th.AssertNoError(t, err)
+	client.Microversion = "1.58"
+
+	r := &allocationV1Resource{meta: &Meta{Client: client}}
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+	return r, schemaResp
+}
+
+// allocationValue builds an allocation object, the attributes not given are
+// null.
+func allocationValue(schemaResp resource.SchemaResponse, values map[string]tftypes.Value) tftypes.Value {
+	objectType := schemaResp.Schema.Type().TerraformType(context.Background()).(tftypes.Object)
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+	}
+	for name, value := range values {
+		attributes[name] = value
+	}
+	return tftypes.NewValue(objectType, attributes)
+}
+
+// tfStringObject builds an object of strings, like an HCL object literal.
+func tfStringObject(values map[string]string) tftypes.Value {
+	attributeTypes := map[string]tftypes.Type{}
+	attributes := map[string]tftypes.Value{}
+	for key, value := range values {
+		attributeTypes[key] = tftypes.String
+		attributes[key] = tftypes.NewValue(tftypes.String, value)
+	}
+	return tftypes.NewValue(tftypes.Object{AttributeTypes: attributeTypes}, attributes)
+}
+
+func TestAllocationCreateOpts(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     allocationCreateOpts
+		expected map[string]any
+		err      string
+	}{
+		{
+			name: "resource class",
+			opts: allocationCreateOpts{CreateOpts: allocations.CreateOpts{
+				Name:           "worker-0",
+				ResourceClass:  "baremetal",
+				CandidateNodes: []string{"node"},
+			}},
+			expected: map[string]any{
+				"name":            "worker-0",
+				"resource_class":  "baremetal",
+				"candidate_nodes": []any{"node"},
+			},
+		},
+		{
+			name: "backfill",
+			opts: allocationCreateOpts{
+				CreateOpts: allocations.CreateOpts{Name: "worker-0"},
+				Node:       "node",
+			},
+			expected: map[string]any{
+				"name": "worker-0",
+				"node": "node",
+			},
+		},
+		{
+			name: "backfill with resource class",
+			opts: allocationCreateOpts{
+				CreateOpts: allocations.CreateOpts{ResourceClass: "baremetal"},
+				Node:       "node",
+			},
+			expected: map[string]any{
+				"resource_class": "baremetal",
+				"node":           "node",
+			},
+		},
+		{
+			name: "missing resource class",
+			opts: allocationCreateOpts{CreateOpts: allocations.CreateOpts{Name: "worker-0"}},
+			err:  "ResourceClass",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			body, err := test.opts.ToAllocationCreateMap()
+			if test.err != "" {
+				th.AssertError(t, err, test.err)
+				return
+			}
+			th.AssertNoError(t, err)
+
+			// Compare the JSON documents, as gophercloud nests the body
+			var got map[string]any
+			b, err := json.Marshal(body)
+			th.AssertNoError(t, err)
+			th.AssertNoError(t, json.Unmarshal(b, &got))
+			if !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("unexpected request body: %v", got)
+			}
+		})
+	}
+}
+
+func TestAllocationCreateBackfill(t *testing.T) {
+	fake := &fakeAllocationIronic{allocation: map[string]any{
+		"uuid":           "alloc",
+		"name":           "worker-0",
+		"resource_class": "baremetal",
+		"node_uuid":      "node",
+		"state":          "active",
+	}}
+	r, schemaResp := newAllocationTestResource(t, fake)
+
+	unknown := func(t tftypes.Type) tftypes.Value { return tftypes.NewValue(t, tftypes.UnknownValue) }
+	plan := allocationValue(schemaResp, map[string]tftypes.Value{
+		"id":              unknown(tftypes.String),
+		"name":            tftypes.NewValue(tftypes.String, "worker-0"),
+		"node":            tftypes.NewValue(tftypes.String, "node"),
+		"resource_class":  unknown(tftypes.String),
+		"candidate_nodes": unknown(tftypes.List{ElementType: tftypes.String}),
+		"node_uuid":       unknown(tftypes.String),
+		"state":           unknown(tftypes.String),
+		"last_error":      unknown(tftypes.String),
+	})
+
+	req := resource.CreateRequest{Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: plan}}
+	resp := resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: allocationValue(schemaResp, nil)},
+	}
+	r.Create(context.Background(), req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	// Backfilling used to create a second allocation on top of this one
+	expected := []map[string]any{{"name": "worker-0", "node": "node"}}
+	if !reflect.DeepEqual(fake.creates, expected) {
+		t.Errorf("unexpected create requests: %v", fake.creates)
+	}
+
+	var model allocationV1ResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.ResourceClass.ValueString() != "baremetal" || model.NodeUUID.ValueString() != "node" {
+		t.Errorf("unexpected state: %+v", model)
+	}
+}
+
+func TestAllocationUpdate(t *testing.T) {
+	tests := []struct {
+		name      string
+		planName  tftypes.Value
+		planExtra tftypes.Value
+		expected  []map[string]any
+	}{
+		{
+			name:      "rename",
+			planName:  tftypes.NewValue(tftypes.String, "worker-1"),
+			planExtra: tfStringObject(map[string]string{"rack": "r1"}),
+			expected: []map[string]any{
+				{"op": "replace", "path": "/name", "value": "worker-1"},
+			},
+		},
+		{
+			name:      "remove name",
+			planName:  tftypes.NewValue(tftypes.String, nil),
+			planExtra: tfStringObject(map[string]string{"rack": "r1"}),
+			expected: []map[string]any{
+				{"op": "remove", "path": "/name"},
+			},
+		},
+		{
+			name:      "rename and change extra",
+			planName:  tftypes.NewValue(tftypes.String, "worker-1"),
+			planExtra: tfStringObject(map[string]string{"rack": "r2"}),
+			expected: []map[string]any{
+				{"op": "replace", "path": "/name", "value": "worker-1"},
+				{"op": "replace", "path": "/extra/rack", "value": "r2"},
+			},
+		},
+		{
+			name:      "remove extra",
+			planName:  tftypes.NewValue(tftypes.String, "worker-0"),
+			planExtra: tftypes.NewValue(tftypes.DynamicPseudoType, nil),
+			expected: []map[string]any{
+				{"op": "replace", "path": "/extra", "value": map[string]any{}},
+			},
+		},
+		{
+			name:      "no change",
+			planName:  tftypes.NewValue(tftypes.String, "worker-0"),
+			planExtra: tfStringObject(map[string]string{"rack": "r1"}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fake := &fakeAllocationIronic{allocation: map[string]any{
+				"uuid":           "alloc",
+				"name":           "worker-0",
+				"resource_class": "baremetal",
+				"node_uuid":      "node",
+				"state":          "active",
+				"extra":          map[string]any{"rack": "r1"},
+			}}
+			r, schemaResp := newAllocationTestResource(t, fake)
+
+			common := map[string]tftypes.Value{
+				"id":             tftypes.NewValue(tftypes.String, "alloc"),
+				"resource_class": tftypes.NewValue(tftypes.String, "baremetal"),
+				"node_uuid":      tftypes.NewValue(tftypes.String, "node"),
+				"state":          tftypes.NewValue(tftypes.String, "active"),
+			}
+			stateValues := map[string]tftypes.Value{
+				"name":  tftypes.NewValue(tftypes.String, "worker-0"),
+				"extra": tfStringObject(map[string]string{"rack": "r1"}),
+			}
+			planValues := map[string]tftypes.Value{
+				"name":  test.planName,
+				"extra": test.planExtra,
+			}
+			for name, value := range common {
+				stateValues[name] = value
+				planValues[name] = value
+			}
+
+			req := resource.UpdateRequest{
+				Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: allocationValue(schemaResp, planValues)},
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: allocationValue(schemaResp, stateValues)},
+			}
+			resp := resource.UpdateResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: allocationValue(schemaResp, stateValues)},
+			}
+			r.Update(context.Background(), req, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+			}
+
+			if test.expected == nil {
+				if len(fake.patches) != 0 {
+					t.Errorf("expected no patch, got %v", fake.patches)
+				}
+				return
+			}
+			if len(fake.patches) != 1 || !reflect.DeepEqual(fake.patches[0], test.expected) {
+				t.Errorf("unexpected patches: %v", fake.patches)
+			}
+		})
+	}
+}
+
+func TestAllocationModifyPlan(t *testing.T) {
+	tests := []struct {
+		name   string
+		config map[string]tftypes.Value
+		err    string
+	}{
+		{
+			name:   "resource class",
+			config: map[string]tftypes.Value{"resource_class": tftypes.NewValue(tftypes.String, "baremetal")},
+		},
+		{
+			name:   "backfill",
+			config: map[string]tftypes.Value{"node": tftypes.NewValue(tftypes.String, "node")},
+		},
+		{
+			name: "neither",
+			config: map[string]tftypes.Value{
+				"candidate_nodes": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+					tftypes.NewValue(tftypes.String, "node"),
+				}),
+			},
+			err: "resource_class must be set unless node is given",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r, schemaResp := newAllocationTestResource(t, &fakeAllocationIronic{})
+			config := allocationValue(schemaResp, test.config)
+
+			req := resource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config},
+				Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: config},
+				State:  tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(config.Type(), nil)},
+			}
+			resp := resource.ModifyPlanResponse{Plan: req.Plan}
+			r.ModifyPlan(context.Background(), req, &resp)
+
+			if test.err == "" {
+				if resp.Diagnostics.HasError() {
+					t.Errorf("unexpected errors: %v", resp.Diagnostics)
+				}
+				return
+			}
+			if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), test.err) {
+				t.Errorf("expected error containing %q, got %v", test.err, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package ironic
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/utils/openstack/clientconfig"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gopkg.in/yaml.v2"
+)
+
+// ironicCloud is a clouds.yaml entry along with the Ironic specific keys
+// understood by the baremetal CLI, which clientconfig.Cloud does not parse.
+type ironicCloud struct {
+	clientconfig.Cloud
+
+	Endpoint          string
+	InspectorEndpoint string
+	APIVersion        string
+}
+
+// ironicCloudExtras describes the Ironic specific keys of a clouds.yaml or
+// secure.yaml entry.
+type ironicCloudExtras struct {
+	BaremetalEndpointOverride              string `yaml:"baremetal_endpoint_override"`
+	BaremetalIntrospectionEndpointOverride string `yaml:"baremetal_introspection_endpoint_override"`
+	BaremetalAPIVersion                    string `yaml:"baremetal_api_version"`
+	Auth                                   struct {
+		Endpoint string `yaml:"endpoint"`
+	} `yaml:"auth"`
+}
+
+// loadCloud reads the named cloud from clouds.yaml, merged with secure.yaml
+// and clouds-public.yaml, using the standard OpenStack lookup locations.
+func loadCloud(name string) (*ironicCloud, error) {
+	cloud, err := clientconfig.GetCloudFromYAML(&clientconfig.ClientOpts{Cloud: name})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ironicCloud{Cloud: *cloud}
+
+	// secure.yaml takes precedence over clouds.yaml.
+	for _, find := range []func() (string, []byte, error){
+		clientconfig.FindAndReadCloudsYAML,
+		clientconfig.FindAndReadSecureCloudsYAML,
+	} {
+		filename, content, err := find()
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+
+		var clouds struct {
+			Clouds map[string]ironicCloudExtras `yaml:"clouds"`
+		}
+		if err := yaml.Unmarshal(content, &clouds); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+		}
+
+		extras, ok := clouds.Clouds[name]
+		if !ok {
+			continue
+		}
+		if v := extras.BaremetalEndpointOverride; v != "" {
+			result.Endpoint = v
+		} else if v := extras.Auth.Endpoint; v != "" {
+			result.Endpoint = v
+		}
+		if v := extras.BaremetalIntrospectionEndpointOverride; v != "" {
+			result.InspectorEndpoint = v
+		}
+		if v := extras.BaremetalAPIVersion; v != "" {
+			result.APIVersion = v
+		}
+	}
+
+	return result, nil
+}
+
+// cloudAuthStrategy maps a clouds.yaml auth_type onto one of the provider's
+// auth strategies.
+func cloudAuthStrategy(cloud *ironicCloud) (string, error) {
+	switch cloud.AuthType {
+	case "none", "noauth":
+		return "noauth", nil
+	case "http_basic":
+		return "http_basic", nil
+	case clientconfig.AuthPassword,
+		clientconfig.AuthV3Password,
+		clientconfig.AuthV3ApplicationCredential:
+		return "keystone", nil
+	case "":
+		// openstacksdk defaults to password authentication, but a cloud
+		// without an auth_url can only be a standalone Ironic.
+		if cloud.AuthInfo != nil && cloud.AuthInfo.AuthURL != "" {
+			return "keystone", nil
+		}
+		return "noauth", nil
+	default:
+		return "", fmt.Errorf("unsupported auth_type %q", cloud.AuthType)
+	}
+}
+
+// cloudInterface maps a clouds.yaml interface or endpoint_type onto the
+// endpoint availability gophercloud understands. clouds.yaml also accepts the
+// Keystone v2 names such as publicURL.
+func cloudInterface(endpointType string) (string, error) {
+	switch strings.TrimSuffix(strings.ToLower(endpointType), "url") {
+	case "":
+		return "", nil
+	case "public":
+		return string(gophercloud.AvailabilityPublic), nil
+	case "internal":
+		return string(gophercloud.AvailabilityInternal), nil
+	case "admin":
+		return string(gophercloud.AvailabilityAdmin), nil
+	default:
+		return "", fmt.Errorf("unsupported interface %q, expected public, internal or admin", endpointType)
+	}
+}
+
+// applyCloud fills the provider configuration attributes that were not set
+// explicitly with the values from the clouds.yaml entry.
+func applyCloud(data *IronicProviderModel, cloud *ironicCloud) error {
+	authStrategy, err := cloudAuthStrategy(cloud)
+	if err != nil {
+		return err
+	}
+	endpointInterface, err := cloudInterface(cloud.EndpointType)
+	if err != nil {
+		return err
+	}
+
+	setIfEmpty := func(attr *types.String, value string) {
+		if attr.ValueString() == "" && value != "" {
+			*attr = types.StringValue(value)
+		}
+	}
+
+	setIfEmpty(&data.AuthStrategy, authStrategy)
+	setIfEmpty(&data.Url, cloud.Endpoint)
+	setIfEmpty(&data.Inspector, cloud.InspectorEndpoint)
+	setIfEmpty(&data.Microversion, cloud.APIVersion)
+	setIfEmpty(&data.Region, cloud.RegionName)
+	setIfEmpty(&data.Interface, endpointInterface)
+
+	if auth := cloud.AuthInfo; auth != nil {
+		setIfEmpty(&data.AuthURL, auth.AuthURL)
+		setIfEmpty(&data.IronicUsername, auth.Username)
+		setIfEmpty(&data.IronicPassword, auth.Password)
+		setIfEmpty(&data.UserDomainName, auth.UserDomainName)
+		setIfEmpty(&data.UserDomainID, auth.UserDomainID)
+		setIfEmpty(&data.ProjectName, auth.ProjectName)
+		setIfEmpty(&data.ProjectID, auth.ProjectID)
+		setIfEmpty(&data.ProjectDomainName, auth.ProjectDomainName)
+		setIfEmpty(&data.ProjectDomainID, auth.ProjectDomainID)
+		setIfEmpty(&data.ApplicationCredentialID, auth.ApplicationCredentialID)
+		setIfEmpty(&data.ApplicationCredentialName, auth.ApplicationCredentialName)
+		setIfEmpty(&data.ApplicationCredentialSecret, auth.ApplicationCredentialSecret)
+
+		// domain_name/domain_id apply to both the user and the project
+		// when the specific ones are missing.
+		setIfEmpty(&data.UserDomainName, auth.DomainName)
+		setIfEmpty(&data.UserDomainID, auth.DomainID)
+		setIfEmpty(&data.ProjectDomainName, auth.DomainName)
+		setIfEmpty(&data.ProjectDomainID, auth.DomainID)
+	}
+
+	setIfEmpty(&data.CACertFile, cloud.CACertFile)
+	setIfEmpty(&data.CertFile, cloud.ClientCertFile)
+	setIfEmpty(&data.KeyFile, cloud.ClientKeyFile)
+	if data.Insecure.IsNull() && cloud.Verify != nil {
+		data.Insecure = types.BoolValue(!*cloud.Verify)
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package ironic
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	th "github.com/metal3-community/terraform-provider-ironic/testhelper"
+)
+
+const testCloudsYAML = `
+clouds:
+  standalone:
+    auth_type: http_basic
+    auth:
+      username: ironic
+      password: from-clouds
+    baremetal_endpoint_override: https://ironic.example.com:6385
+    baremetal_introspection_endpoint_override: https://ironic.example.com:5050
+    baremetal_api_version: "1.81"
+    cacert: /etc/ironic/ca.crt
+    verify: false
+  keystone:
+    auth:
+      auth_url: https://keystone.example.com/v3
+      username: admin
+      password: secret
+      project_name: baremetal
+      domain_name: Default
+    region_name: RegionTwo
+    interface: internal
+  legacy:
+    auth:
+      auth_url: https://keystone.example.com/v3
+    endpoint_type: adminURL
+  unsupported:
+    auth_type: token
+  unsupported-interface:
+    auth:
+      auth_url: https://keystone.example.com/v3
+    interface: private
+`
+
+const testSecureYAML = `
+clouds:
+  standalone:
+    auth:
+      password: from-secure
+`
+
+// writeClouds writes clouds.yaml and secure.yaml to a temporary working
+// directory, where the clientconfig lookup finds them first.
+func writeClouds(t *testing.T) {
+	t.Helper()
+
+	dir := t.TempDir()
+	th.AssertNoError(t, os.WriteFile(filepath.Join(dir, "clouds.yaml"), []byte(testCloudsYAML), 0o600))
+	th.AssertNoError(t, os.WriteFile(filepath.Join(dir, "secure.yaml"), []byte(testSecureYAML), 0o600))
+	t.Setenv("OS_CLIENT_CONFIG_FILE", "")
+	t.Chdir(dir)
+}
+
+func TestApplyCloudStandalone(t *testing.T) {
+	writeClouds(t)
+
+	cloud, err := loadCloud("standalone")
+	th.AssertNoError(t, err)
+
+	data := IronicProviderModel{
+		Microversion: types.StringValue("1.99"),
+	}
+	th.AssertNoError(t, applyCloud(&data, cloud))
+
+	expected := map[string][2]string{
+		"auth_strategy":   {data.AuthStrategy.ValueString(), "http_basic"},
+		"url":             {data.Url.ValueString(), "https://ironic.example.com:6385"},
+		"inspector":       {data.Inspector.ValueString(), "https://ironic.example.com:5050"},
+		"microversion":    {data.Microversion.ValueString(), "1.99"},
+		"ironic_username": {data.IronicUsername.ValueString(), "ironic"},
+		"ironic_password": {data.IronicPassword.ValueString(), "from-secure"},
+	}
+	for name, values := range expected {
+		if values[0] != values[1] {
+			t.Errorf("%s = %q, expected %q", name, values[0], values[1])
+		}
+	}
+
+	if data.CACertFile.ValueString() != "/etc/ironic/ca.crt" || !data.Insecure.ValueBool() {
+		t.Errorf("unexpected TLS settings: cacert_file=%s insecure=%s", data.CACertFile, data.Insecure)
+	}
+}
+
+func TestApplyCloudKeystone(t *testing.T) {
+	writeClouds(t)
+
+	cloud, err := loadCloud("keystone")
+	th.AssertNoError(t, err)
+
+	data := IronicProviderModel{}
+	th.AssertNoError(t, applyCloud(&data, cloud))
+
+	if data.AuthStrategy.ValueString() != "keystone" {
+		t.Errorf("expected keystone auth strategy, got %q", data.AuthStrategy.ValueString())
+	}
+
+	authOpts, endpointOpts := keystoneOptions(data)
+	if authOpts.IdentityEndpoint != "https://keystone.example.com/v3" {
+		t.Errorf("unexpected identity endpoint %q", authOpts.IdentityEndpoint)
+	}
+	if authOpts.Scope == nil || authOpts.Scope.ProjectName != "baremetal" || authOpts.Scope.DomainName != "Default" {
+		t.Errorf("unexpected scope %+v", authOpts.Scope)
+	}
+	if endpointOpts.Region != "RegionTwo" || endpointOpts.Availability != "internal" {
+		t.Errorf("unexpected endpoint options %+v", endpointOpts)
+	}
+}
+
+func TestApplyCloudErrors(t *testing.T) {
+	writeClouds(t)
+
+	_, err := loadCloud("missing")
+	th.AssertError(t, err, "missing")
+
+	cloud, err := loadCloud("unsupported")
+	th.AssertNoError(t, err)
+	th.AssertError(t, applyCloud(&IronicProviderModel{}, cloud), "unsupported auth_type")
+
+	cloud, err = loadCloud("unsupported-interface")
+	th.AssertNoError(t, err)
+	th.AssertError(t, applyCloud(&IronicProviderModel{}, cloud), `unsupported interface "private"`)
+}
+
+func TestApplyCloudLegacyInterface(t *testing.T) {
+	writeClouds(t)
+
+	cloud, err := loadCloud("legacy")
+	th.AssertNoError(t, err)
+
+	data := IronicProviderModel{}
+	th.AssertNoError(t, applyCloud(&data, cloud))
+
+	if data.Interface.ValueString() != "admin" {
+		t.Errorf("expected the admin interface, got %q", data.Interface.ValueString())
+	}
+}
@@ -0,0 +1,175 @@
+package ironic
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/conductors"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &ConductorDataSource{}
+	_ datasource.DataSourceWithConfigure = &ConductorDataSource{}
+)
+
+// conductorMicroversions lists the microversions needed by the conductor
+// data sources, the API appeared in 1.49.
+var conductorMicroversions = []microversionRequirement{
+	{Version: "1.49"},
+}
+
+// ConductorDataSource describes an Ironic conductor.
+type ConductorDataSource struct {
+	meta *Meta
+}
+
+// conductorModel describes a conductor.
+type conductorModel struct {
+	Hostname       types.String `tfsdk:"hostname"`
+	ConductorGroup types.String `tfsdk:"conductor_group"`
+	Alive          types.Bool   `tfsdk:"alive"`
+	Drivers        []string     `tfsdk:"drivers"`
+	UpdatedAt      types.String `tfsdk:"updated_at"`
+}
+
+// conductorDataSourceModel describes the data source data model.
+type conductorDataSourceModel struct {
+	conductorModel
+	ID types.String `tfsdk:"id"`
+}
+
+func NewConductorDataSource() datasource.DataSource {
+	return &ConductorDataSource{}
+}
+
+func (d *ConductorDataSource) Metadata(
+	ctx context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_conductor"
+}
+
+func (d *ConductorDataSource) Schema(
+	ctx context.Context,
+	req datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	attributes := conductorAttributes()
+	attributes["hostname"] = schema.StringAttribute{
+		MarkdownDescription: "The hostname of the conductor.",
+		Required:            true,
+	}
+	attributes["id"] = schema.StringAttribute{
+		MarkdownDescription: "The hostname of the conductor.",
+		Computed:            true,
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Describes an Ironic conductor: whether it is alive, its conductor group and the drivers it serves.",
+		Attributes:          attributes,
+	}
+}
+
+// conductorAttributes returns the computed attributes describing a
+// conductor.
+func conductorAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"hostname": schema.StringAttribute{
+			MarkdownDescription: "The hostname of the conductor.",
+			Computed:            true,
+		},
+		"conductor_group": schema.StringAttribute{
+			MarkdownDescription: "The conductor group of the conductor, empty for the default group.",
+			Computed:            true,
+		},
+		"alive": schema.BoolAttribute{
+			MarkdownDescription: "Whether the conductor is alive, i.e. has recently reported to Ironic.",
+			Computed:            true,
+		},
+		"drivers": schema.ListAttribute{
+			MarkdownDescription: "The drivers enabled on the conductor.",
+			ElementType:         types.StringType,
+			Computed:            true,
+		},
+		"updated_at": schema.StringAttribute{
+			MarkdownDescription: "When the conductor last reported to Ironic, in RFC 3339 format.",
+			Computed:            true,
+		},
+	}
+}
+
+func (d *ConductorDataSource) Configure(
+	ctx context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*Meta)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf(
+				"Expected *Clients, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+		return
+	}
+
+	d.meta = clients
+}
+
+func (d *ConductorDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var hostname types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("hostname"), &hostname)...)
+	resp.Diagnostics.Append(
+		d.meta.validateMicroversions(ctx, "ironic_conductor", req.Config, conductorMicroversions)...,
+	)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	conductor, err := conductors.Get(ctx, d.meta.Client, hostname.ValueString()).Extract()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Conductor",
+			fmt.Sprintf("Could not read conductor %s: %s", hostname.ValueString(), err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, conductorDataSourceModel{
+		conductorModel: newConductorModel(*conductor),
+		ID:             types.StringValue(conductor.Hostname),
+	})...)
+}
+
+// newConductorModel describes a conductor returned by Ironic.
+func newConductorModel(conductor conductors.Conductor) conductorModel {
+	updatedAt := types.StringNull()
+	if !conductor.UpdatedAt.IsZero() {
+		updatedAt = types.StringValue(conductor.UpdatedAt.Format(time.RFC3339))
+	}
+
+	return conductorModel{
+		Hostname:       types.StringValue(conductor.Hostname),
+		ConductorGroup: types.StringValue(conductor.ConductorGroup),
+		Alive:          types.BoolValue(conductor.Alive),
+		Drivers:        append([]string{}, conductor.Drivers...),
+		UpdatedAt:      updatedAt,
+	}
+}
@@ -0,0 +1,93 @@
+package ironic
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/noauth"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	th "github.com/metal3-community/terraform-provider-ironic/testhelper"
+)
+
+func TestConductorDataSourcesRead(t *testing.T) {
+	all := []map[string]any{
+		{
+			"hostname":        "conductor-0",
+			"conductor_group": "rack-1",
+			"alive":           true,
+			"drivers":         []string{"ipmi", "redfish"},
+			"updated_at":      "2026-10-16T08:39:21+00:00",
+		},
+		{
+			"hostname":        "conductor-1",
+			"conductor_group": "rack-1",
+			"alive":           false,
+			"drivers":         []string{"redfish"},
+		},
+		{
+			"hostname":        "conductor-2",
+			"conductor_group": "",
+			"alive":           true,
+			"drivers":         []string{"redfish"},
+		},
+	}
+
+	var listQuery string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/conductors", func(w http.ResponseWriter, r *http.Request) {
+		listQuery = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"conductors": all})
+	})
+	mux.HandleFunc("/v1/conductors/conductor-0", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(all[0])
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := noauth.NewBareMetalNoAuth(noauth.EndpointOpts{
+		IronicEndpoint: server.URL + "/v1",
+	})
+	th.AssertNoError(t, err)
+	meta := &Meta{Client: client}
+	ctx := context.Background()
+
+	t.Run("conductor", func(t *testing.T) {
+		resp := readDataSource(t, &ConductorDataSource{meta: meta}, map[string]tftypes.Value{
+			"hostname": tftypes.NewValue(tftypes.String, "conductor-0"),
+		})
+		var state conductorDataSourceModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+		}
+		if state.ConductorGroup.ValueString() != "rack-1" || !state.Alive.ValueBool() ||
+			!reflect.DeepEqual(state.Drivers, []string{"ipmi", "redfish"}) ||
+			state.UpdatedAt.ValueString() != "2026-10-16T08:39:21Z" {
+			t.Errorf("unexpected conductor: %v", state)
+		}
+	})
+
+	t.Run("conductors", func(t *testing.T) {
+		resp := readDataSource(t, &ConductorsDataSource{meta: meta}, map[string]tftypes.Value{
+			"conductor_group": tftypes.NewValue(tftypes.String, "RACK-1"),
+			"alive":           tftypes.NewValue(tftypes.Bool, true),
+		})
+		var state conductorsDataSourceModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+		}
+		if listQuery != "detail=true&limit=200" {
+			t.Errorf("unexpected query %q", listQuery)
+		}
+		if len(state.Conductors) != 1 || state.Conductors[0].Hostname.ValueString() != "conductor-0" {
+			t.Errorf("unexpected conductors: %v", state.Conductors)
+		}
+	})
+}
@@ -0,0 +1,129 @@
+package ironic
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/conductors"
+	"github.com/gophercloud/gophercloud/v2/pagination"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// listConductors lists all the conductors, with their details.
+func listConductors(
+	ctx context.Context,
+	client *gophercloud.ServiceClient,
+) ([]conductors.Conductor, error) {
+	opts := conductors.ListOpts{Detail: true}
+	return listAll(ctx,
+		func(limit int, marker string) pagination.Pager {
+			opts.Limit, opts.Marker = limit, marker
+			return conductors.List(client, opts)
+		},
+		conductors.ExtractConductors,
+		func(conductor conductors.Conductor) string { return conductor.Hostname },
+	)
+}
+
+// conductors returns the conductors, listed once per provider unless refresh
+// is set.
+func (m *Meta) conductors(ctx context.Context, refresh bool) ([]conductors.Conductor, error) {
+	m.conductorsMu.Lock()
+	defer m.conductorsMu.Unlock()
+
+	if m.conductorList != nil && !refresh {
+		return m.conductorList, nil
+	}
+
+	found, err := listConductors(ctx, m.Client)
+	if err != nil {
+		return nil, err
+	}
+	m.conductorList = append([]conductors.Conductor{}, found...)
+	return m.conductorList, nil
+}
+
+// validateConductorGroup warns unless an alive conductor of the configured
+// conductor_group of a node serves its driver, when either changes.
+func (r *NodeResource) validateConductorGroup(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var group, driver types.String
+	diags.Append(req.Config.GetAttribute(ctx, path.Root("conductor_group"), &group)...)
+	diags.Append(req.Config.GetAttribute(ctx, path.Root("driver"), &driver)...)
+	if diags.HasError() || group.IsNull() || group.IsUnknown() || driver.IsUnknown() {
+		return diags
+	}
+
+	if !req.State.Raw.IsNull() {
+		var priorGroup, priorDriver types.String
+		diags.Append(req.State.GetAttribute(ctx, path.Root("conductor_group"), &priorGroup)...)
+		diags.Append(req.State.GetAttribute(ctx, path.Root("driver"), &priorDriver)...)
+		if diags.HasError() || (group.Equal(priorGroup) && driver.Equal(priorDriver)) {
+			return diags
+		}
+	}
+
+	// Older Ironic APIs do not list conductors
+	if r.meta.checkMicroversion("Listing conductors", conductorMicroversions[0].Version) != nil {
+		return diags
+	}
+
+	// The conductors are cached, list them again before reporting a
+	// conductor that may have come up since
+	var checkErr error
+	for _, refresh := range []bool{false, true} {
+		found, err := r.meta.conductors(ctx, refresh)
+		if err != nil {
+			diags.AddAttributeWarning(
+				path.Root("conductor_group"),
+				"Unable to Validate conductor_group",
+				fmt.Sprintf("Could not list conductors: %s", err),
+			)
+			return diags
+		}
+		if checkErr = checkConductorGroup(found, group.ValueString(), driver.ValueString()); checkErr == nil {
+			return diags
+		}
+	}
+
+	// Conductors may be down for maintenance or not report their drivers
+	// yet, so this does not block the plan
+	diags.AddAttributeWarning(path.Root("conductor_group"), "Invalid Conductor Group", checkErr.Error())
+	return diags
+}
+
+// checkConductorGroup returns an error unless an alive conductor of group
+// serves driver. Ironic matches conductor groups case insensitively.
+func checkConductorGroup(found []conductors.Conductor, group, driver string) error {
+	var alive, groups []string
+	for _, conductor := range found {
+		if !slices.Contains(groups, conductor.ConductorGroup) {
+			groups = append(groups, conductor.ConductorGroup)
+		}
+		if !strings.EqualFold(conductor.ConductorGroup, group) || !conductor.Alive {
+			continue
+		}
+		if slices.Contains(conductor.Drivers, driver) {
+			return nil
+		}
+		alive = append(alive, conductor.Hostname)
+	}
+
+	if len(alive) > 0 {
+		return fmt.Errorf("no conductor of conductor group %q serves the %s driver, "+
+			"it is not enabled on %s", group, driver, strings.Join(alive, ", "))
+	}
+	slices.Sort(groups)
+	return fmt.Errorf("no alive conductor is in conductor group %q, the conductor groups are %q",
+		group, groups)
+}
@@ -0,0 +1,137 @@
+package ironic
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/noauth"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/conductors"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	th "github.com/metal3-community/terraform-provider-ironic/testhelper"
+)
+
+func TestCheckConductorGroup(t *testing.T) {
+	found := []conductors.Conductor{
+		{Hostname: "conductor-0", ConductorGroup: "rack-1", Alive: true, Drivers: []string{"ipmi"}},
+		{Hostname: "conductor-1", ConductorGroup: "rack-1", Alive: false, Drivers: []string{"redfish"}},
+		{Hostname: "conductor-2", ConductorGroup: "rack-2", Alive: false, Drivers: []string{"redfish"}},
+		{Hostname: "conductor-3", Alive: true, Drivers: []string{"redfish"}},
+	}
+
+	tests := []struct {
+		group  string
+		driver string
+		err    string
+	}{
+		{group: "rack-1", driver: "ipmi"},
+		{group: "RACK-1", driver: "ipmi"},
+		{group: "", driver: "redfish"},
+		{group: "rack-1", driver: "redfish", err: `no conductor of conductor group "rack-1" serves the redfish driver, it is not enabled on conductor-0`},
+		{group: "rack-2", driver: "redfish", err: `no alive conductor is in conductor group "rack-2"`},
+		{group: "rack-3", driver: "redfish", err: `the conductor groups are ["" "rack-1" "rack-2"]`},
+	}
+
+	for _, test := range tests {
+		err := checkConductorGroup(found, test.group, test.driver)
+		if test.err == "" {
+			if err != nil {
+				t.Errorf("%s/%s: unexpected error %s", test.group, test.driver, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%s/%s: expected error containing %q, got %v", test.group, test.driver, test.err, err)
+		}
+	}
+}
+
+func TestValidateConductorGroup(t *testing.T) {
+	tests := []struct {
+		name     string
+		alive    []bool
+		requests int
+		warning  string
+	}{
+		{
+			name:     "alive",
+			alive:    []bool{true},
+			requests: 1,
+		},
+		{
+			name:     "came up since listed",
+			alive:    []bool{false, true},
+			requests: 2,
+		},
+		{
+			name:     "down",
+			alive:    []bool{false, false},
+			requests: 2,
+			warning:  `no alive conductor is in conductor group "rack-1"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			requests := 0
+			mux := http.NewServeMux()
+			mux.HandleFunc("/v1/conductors", func(w http.ResponseWriter, r *http.Request) {
+				alive := test.alive[min(requests, len(test.alive)-1)]
+				requests++
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(map[string]any{"conductors": []any{map[string]any{
+					"hostname":        "conductor-0",
+					"conductor_group": "rack-1",
+					"alive":           alive,
+					"drivers":         []string{"redfish"},
+				}}})
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			client, err := noauth.NewBareMetalNoAuth(noauth.EndpointOpts{
+				IronicEndpoint: server.URL + "/v1",
+			})
+			th.AssertNoError(t, err)
+
+			ctx := context.Background()
+			r := &NodeResource{meta: &Meta{Client: client}}
+			var schemaResp resource.SchemaResponse
+			r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+			// The conductors were listed before, for another node of the plan
+			_, err = r.meta.conductors(ctx, false)
+			th.AssertNoError(t, err)
+
+			config := allocationValue(schemaResp, map[string]tftypes.Value{
+				"conductor_group": tftypes.NewValue(tftypes.String, "rack-1"),
+				"driver":          tftypes.NewValue(tftypes.String, "redfish"),
+			})
+			diags := r.validateConductorGroup(ctx, resource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config},
+				State:  tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(config.Type(), nil)},
+			})
+
+			if diags.HasError() {
+				t.Fatalf("unexpected errors: %v", diags)
+			}
+			if requests != test.requests {
+				t.Errorf("expected %d requests, got %d", test.requests, requests)
+			}
+			if test.warning == "" {
+				if len(diags.Warnings()) > 0 {
+					t.Errorf("unexpected warnings: %v", diags)
+				}
+				return
+			}
+			if len(diags.Warnings()) != 1 || !strings.Contains(diags.Warnings()[0].Detail(), test.warning) {
+				t.Errorf("expected warning containing %q, got %v", test.warning, diags)
+			}
+		})
+	}
+}
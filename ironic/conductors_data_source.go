@@ -0,0 +1,166 @@
+package ironic
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &ConductorsDataSource{}
+	_ datasource.DataSourceWithConfigure = &ConductorsDataSource{}
+)
+
+// ConductorsDataSource lists the Ironic conductors.
+type ConductorsDataSource struct {
+	meta *Meta
+}
+
+// conductorsDataSourceModel describes the data source data model.
+type conductorsDataSourceModel struct {
+	ID             types.String     `tfsdk:"id"`
+	ConductorGroup types.String     `tfsdk:"conductor_group"`
+	Alive          types.Bool       `tfsdk:"alive"`
+	Driver         types.String     `tfsdk:"driver"`
+	Conductors     []conductorModel `tfsdk:"conductors"`
+}
+
+func NewConductorsDataSource() datasource.DataSource {
+	return &ConductorsDataSource{}
+}
+
+func (d *ConductorsDataSource) Metadata(
+	ctx context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_conductors"
+}
+
+func (d *ConductorsDataSource) Schema(
+	ctx context.Context,
+	req datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the Ironic conductors, with their conductor group and the drivers they serve. All filters are optional and combined.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Data source identifier.",
+				Computed:            true,
+			},
+			"conductor_group": schema.StringAttribute{
+				MarkdownDescription: "Only list the conductors of this conductor group, empty for the default group.",
+				Optional:            true,
+			},
+			"alive": schema.BoolAttribute{
+				MarkdownDescription: "Only list alive conductors when true, or dead ones when false.",
+				Optional:            true,
+			},
+			"driver": schema.StringAttribute{
+				MarkdownDescription: "Only list the conductors serving this driver.",
+				Optional:            true,
+			},
+			"conductors": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching conductors, in the order returned by Ironic.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: conductorAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func (d *ConductorsDataSource) Configure(
+	ctx context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*Meta)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf(
+				"Expected *Clients, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+		return
+	}
+
+	d.meta = clients
+}
+
+func (d *ConductorsDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var config conductorsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(
+		d.meta.validateMicroversions(ctx, "ironic_conductors", req.Config, conductorMicroversions)...,
+	)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Ironic cannot filter conductors
+	found, err := listConductors(ctx, d.meta.Client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to List Conductors",
+			fmt.Sprintf("Unable to list conductors: %s", err),
+		)
+		return
+	}
+
+	config.Conductors = []conductorModel{}
+	for _, conductor := range found {
+		if !config.ConductorGroup.IsNull() &&
+			!strings.EqualFold(conductor.ConductorGroup, config.ConductorGroup.ValueString()) {
+			continue
+		}
+		if !config.Alive.IsNull() && conductor.Alive != config.Alive.ValueBool() {
+			continue
+		}
+		if !config.Driver.IsNull() && !slices.Contains(conductor.Drivers, config.Driver.ValueString()) {
+			continue
+		}
+		config.Conductors = append(config.Conductors, newConductorModel(conductor))
+	}
+	tflog.Debug(ctx, "Listed conductors", map[string]any{
+		"listed":   len(found),
+		"matching": len(config.Conductors),
+	})
+
+	// The filters identify the data source
+	filters := url.Values{}
+	if !config.ConductorGroup.IsNull() {
+		filters.Set("conductor_group", config.ConductorGroup.ValueString())
+	}
+	if !config.Alive.IsNull() {
+		filters.Set("alive", strconv.FormatBool(config.Alive.ValueBool()))
+	}
+	if !config.Driver.IsNull() {
+		filters.Set("driver", config.Driver.ValueString())
+	}
+	config.ID = types.StringValue("conductors?" + filters.Encode())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
@@ -0,0 +1,201 @@
+package ironic
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/metal3-community/terraform-provider-ironic/ironic/util"
+)
+
+// rebuildInstanceInfoKeys are the instance_info keys that only take effect
+// when the node is deployed again. Other keys are patched in place.
+var rebuildInstanceInfoKeys = []string{
+	"capabilities",
+	"ephemeral_format",
+	"ephemeral_gb",
+	"image_checksum",
+	"image_disk_format",
+	"image_os_hash_algo",
+	"image_os_hash_value",
+	"image_source",
+	"image_type",
+	"kernel",
+	"ramdisk",
+	"root_device",
+	"root_gb",
+	"swap_mb",
+}
+
+// instanceInfoNeedsRebuild reports whether the change from prior to planned
+// instance_info touches a key in rebuildInstanceInfoKeys.
+func instanceInfoNeedsRebuild(ctx context.Context, prior, planned types.Dynamic) (bool, error) {
+	if prior.Equal(planned) {
+		return false, nil
+	}
+	if planned.IsUnknown() || planned.IsUnderlyingValueUnknown() {
+		return true, nil
+	}
+
+	priorMap, err := util.DynamicToMap(ctx, prior)
+	if err != nil {
+		return false, err
+	}
+	plannedMap, err := util.DynamicToMap(ctx, planned)
+	if err != nil {
+		return false, err
+	}
+
+	return slices.ContainsFunc(rebuildInstanceInfoKeys, func(key string) bool {
+		return !reflect.DeepEqual(priorMap[key], plannedMap[key])
+	}), nil
+}
+
+// validateRebuildRescue reports a rebuild planned while the node stays
+// rescued, Ironic only rebuilds active nodes.
+func validateRebuildRescue(plan, state *deploymentResourceModel, reasons []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if len(reasons) > 0 && state.Rescue.ValueBool() && plan.Rescue.ValueBool() {
+		diags.AddAttributeError(
+			path.Root("rescue"),
+			"Rebuild of a Rescued Node",
+			fmt.Sprintf("Changing %s rebuilds the node, which Ironic only does for active nodes. "+
+				"Set rescue to false to unrescue the node before it is rebuilt.",
+				strings.Join(reasons, ", ")),
+		)
+	}
+	return diags
+}
+
+// deploymentRebuildReasons returns the attributes whose change from state to
+// plan needs the node to be rebuilt.
+func deploymentRebuildReasons(
+	ctx context.Context,
+	plan, state *deploymentResourceModel,
+) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var reasons []string
+
+	rebuild, err := instanceInfoNeedsRebuild(ctx, state.InstanceInfo, plan.InstanceInfo)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("instance_info"),
+			"Error Converting Instance Info",
+			fmt.Sprintf("Could not convert instance_info to map: %s", err),
+		)
+		return nil, diags
+	}
+	if rebuild {
+		reasons = append(reasons, "instance_info")
+	}
+
+	changes := []struct {
+		name    string
+		changed bool
+	}{
+		{"deploy_steps", !plan.DeploySteps.Equal(state.DeploySteps)},
+		{"user_data", !plan.UserData.Equal(state.UserData)},
+		{"user_data_url", !plan.UserDataURL.Equal(state.UserDataURL)},
+		{"user_data_url_ca_cert", !plan.UserDataURLCaCert.Equal(state.UserDataURLCaCert)},
+		{"user_data_url_headers", !plan.UserDataURLHeaders.Equal(state.UserDataURLHeaders)},
+		{"network_data", !plan.NetworkData.Equal(state.NetworkData)},
+		{"metadata", !plan.Metadata.Equal(state.Metadata)},
+		{"fixed_ips", !plan.FixedIPs.Equal(state.FixedIPs)},
+	}
+	for _, change := range changes {
+		if change.changed {
+			reasons = append(reasons, change.name)
+		}
+	}
+
+	return reasons, diags
+}
+
+// addRebuildWarning warns in the plan that changing the attribute rebuilds
+// the deployed node.
+func addRebuildWarning(diags *diag.Diagnostics, attribute path.Path) {
+	diags.AddAttributeWarning(
+		attribute,
+		"Deployment Will Be Rebuilt",
+		fmt.Sprintf(
+			"Changing %s rebuilds the node: it is deployed again with a new config drive, which erases its disks.",
+			attribute,
+		),
+	)
+}
+
+// rebuildOnChange returns a plan modifier that warns when a change of the
+// attribute rebuilds the deployed node, instead of replacing the deployment.
+func rebuildOnChange() rebuildModifier {
+	return rebuildModifier{}
+}
+
+type rebuildModifier struct{}
+
+func (m rebuildModifier) Description(ctx context.Context) string {
+	return "Changing this value rebuilds the deployed node."
+}
+
+func (m rebuildModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+// isUpdate reports whether the plan updates an existing deployment.
+func (m rebuildModifier) isUpdate(state, plan interface{ IsNull() bool }) bool {
+	return !state.IsNull() && !plan.IsNull()
+}
+
+func (m rebuildModifier) PlanModifyString(
+	ctx context.Context,
+	req planmodifier.StringRequest,
+	resp *planmodifier.StringResponse,
+) {
+	if m.isUpdate(req.State.Raw, req.Plan.Raw) && !req.PlanValue.Equal(req.StateValue) {
+		addRebuildWarning(&resp.Diagnostics, req.Path)
+	}
+}
+
+func (m rebuildModifier) PlanModifyList(
+	ctx context.Context,
+	req planmodifier.ListRequest,
+	resp *planmodifier.ListResponse,
+) {
+	if m.isUpdate(req.State.Raw, req.Plan.Raw) && !req.PlanValue.Equal(req.StateValue) {
+		addRebuildWarning(&resp.Diagnostics, req.Path)
+	}
+}
+
+// PlanModifyDynamic only warns about instance_info changes that touch
+// rebuildInstanceInfoKeys, the other keys are patched in place.
+func (m rebuildModifier) PlanModifyDynamic(
+	ctx context.Context,
+	req planmodifier.DynamicRequest,
+	resp *planmodifier.DynamicResponse,
+) {
+	if !m.isUpdate(req.State.Raw, req.Plan.Raw) {
+		return
+	}
+
+	rebuild := !req.PlanValue.Equal(req.StateValue)
+	if req.Path.Equal(path.Root("instance_info")) {
+		var err error
+		rebuild, err = instanceInfoNeedsRebuild(ctx, req.StateValue, req.PlanValue)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				req.Path,
+				"Error Converting Instance Info",
+				fmt.Sprintf("Could not convert instance_info to map: %s", err),
+			)
+			return
+		}
+	}
+	if rebuild {
+		addRebuildWarning(&resp.Diagnostics, req.Path)
+	}
+}
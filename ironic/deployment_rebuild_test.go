@@ -0,0 +1,153 @@
+package ironic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/noauth"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/metal3-community/terraform-provider-ironic/ironic/util"
+	th "github.com/metal3-community/terraform-provider-ironic/testhelper"
+)
+
+func TestDeploymentRebuildReasons(t *testing.T) {
+	ctx := context.Background()
+
+	instanceInfo := func(values map[string]any) types.Dynamic {
+		value, err := util.MapToDynamic(ctx, values)
+		th.AssertNoError(t, err)
+		return value
+	}
+
+	model := func(instanceInfo, userData types.Dynamic) deploymentResourceModel {
+		return deploymentResourceModel{
+			InstanceInfo:       instanceInfo,
+			DeploySteps:        types.ListNull(types.ObjectType{}),
+			UserData:           userData,
+			UserDataURL:        types.StringNull(),
+			UserDataURLCaCert:  types.StringNull(),
+			UserDataURLHeaders: types.DynamicNull(),
+			NetworkData:        types.DynamicNull(),
+			Metadata:           types.DynamicNull(),
+			FixedIPs:           types.ListNull(types.ObjectType{}),
+		}
+	}
+
+	state := model(instanceInfo(map[string]any{
+		"image_source": "http://172.22.0.1/images/fedora-40.qcow2",
+		"display_name": "worker-0",
+	}), types.DynamicNull())
+
+	tests := []struct {
+		name     string
+		plan     deploymentResourceModel
+		expected []string
+	}{
+		{
+			name: "metadata only",
+			plan: model(instanceInfo(map[string]any{
+				"image_source": "http://172.22.0.1/images/fedora-40.qcow2",
+				"display_name": "worker-0.example.com",
+			}), types.DynamicNull()),
+		},
+		{
+			name: "new image",
+			plan: model(instanceInfo(map[string]any{
+				"image_source": "http://172.22.0.1/images/fedora-41.qcow2",
+				"display_name": "worker-0",
+			}), types.DynamicNull()),
+			expected: []string{"instance_info"},
+		},
+		{
+			name:     "new user data",
+			plan:     model(state.InstanceInfo, types.DynamicValue(types.StringValue("#cloud-config"))),
+			expected: []string{"user_data"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			reasons, diags := deploymentRebuildReasons(ctx, &test.plan, &state)
+			if diags.HasError() {
+				t.Fatalf("unexpected errors: %v", diags)
+			}
+			if !reflect.DeepEqual(reasons, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, reasons)
+			}
+		})
+	}
+}
+
+func TestValidateRebuildRescue(t *testing.T) {
+	tests := []struct {
+		name    string
+		state   bool
+		plan    bool
+		reasons []string
+		err     bool
+	}{
+		{name: "rebuild while rescued", state: true, plan: true, reasons: []string{"user_data"}, err: true},
+		{name: "unrescue and rebuild", state: true, plan: false, reasons: []string{"user_data"}},
+		{name: "rebuild and rescue", state: false, plan: true, reasons: []string{"user_data"}},
+		{name: "stay rescued", state: true, plan: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			plan := deploymentResourceModel{Rescue: types.BoolValue(test.plan)}
+			state := deploymentResourceModel{Rescue: types.BoolValue(test.state)}
+
+			diags := validateRebuildRescue(&plan, &state, test.reasons)
+			if diags.HasError() != test.err {
+				t.Errorf("expected error %t, got %v", test.err, diags)
+			}
+		})
+	}
+}
+
+func TestProvisionWorkflowRebuild(t *testing.T) {
+	defer func(interval time.Duration) { workflowPollInterval = interval }(workflowPollInterval)
+	workflowPollInterval = 10 * time.Millisecond
+
+	state := nodes.Active
+	var requests []map[string]any
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/nodes/node/states/provision", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		requests = append(requests, body)
+		state = nodes.Deploying
+		w.WriteHeader(http.StatusAccepted)
+	})
+	mux.HandleFunc("/v1/nodes/node", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"uuid": "node", "provision_state": %q}`, state)
+		if state == nodes.Deploying {
+			state = nodes.Active
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := noauth.NewBareMetalNoAuth(noauth.EndpointOpts{
+		IronicEndpoint: server.URL + "/v1",
+	})
+	th.AssertNoError(t, err)
+
+	configDrive := "H4sICDw2"
+	err = ChangeProvisionStateToTarget(context.Background(), client, "node", nodes.TargetRebuild, &configDrive, nil, nil, nil)
+	th.AssertNoError(t, err)
+
+	// The node is active before and after, it must still be rebuilt once
+	if len(requests) != 1 || requests[0]["target"] != "rebuild" || requests[0]["configdrive"] != configDrive {
+		t.Errorf("unexpected provision state requests: %v", requests)
+	}
+}
@@ -15,12 +15,13 @@ import (
 	utilgc "github.com/gophercloud/utils/openstack/baremetal/v1/nodes"
 	retryablehttp "github.com/hashicorp/go-retryablehttp"
 	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/dynamicplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -35,8 +36,17 @@ var (
 	_ resource.Resource                = &deploymentResource{}
 	_ resource.ResourceWithConfigure   = &deploymentResource{}
 	_ resource.ResourceWithImportState = &deploymentResource{}
+	_ resource.ResourceWithModifyPlan  = &deploymentResource{}
 )
 
+// deploymentMicroversions lists the deployment attributes that need a newer
+// microversion than the base Ironic API.
+var deploymentMicroversions = []microversionRequirement{
+	{Attribute: "rescue", Version: "1.38"},
+	{Attribute: "rescue_interface", Version: "1.38"},
+	{Attribute: "deploy_steps", Version: "1.69"},
+}
+
 // deploymentResource defines the resource implementation.
 type deploymentResource struct {
 	meta *Meta
@@ -56,20 +66,24 @@ type deployStepModel struct {
 
 // deploymentResourceModel describes the resource data model.
 type deploymentResourceModel struct {
-	ID                 types.String  `tfsdk:"id"`
-	Name               types.String  `tfsdk:"name"`
-	NodeUUID           types.String  `tfsdk:"node_uuid"`
-	InstanceInfo       types.Dynamic `tfsdk:"instance_info"`
-	DeploySteps        types.List    `tfsdk:"deploy_steps"`
-	UserData           types.Dynamic `tfsdk:"user_data"`
-	UserDataURL        types.String  `tfsdk:"user_data_url"`
-	UserDataURLCaCert  types.String  `tfsdk:"user_data_url_ca_cert"`
-	UserDataURLHeaders types.Dynamic `tfsdk:"user_data_url_headers"`
-	NetworkData        types.Dynamic `tfsdk:"network_data"`
-	Metadata           types.Dynamic `tfsdk:"metadata"`
-	FixedIPs           types.List    `tfsdk:"fixed_ips"`
-	ProvisionState     types.String  `tfsdk:"provision_state"`
-	LastError          types.String  `tfsdk:"last_error"`
+	ID                 types.String   `tfsdk:"id"`
+	Name               types.String   `tfsdk:"name"`
+	NodeUUID           types.String   `tfsdk:"node_uuid"`
+	InstanceInfo       types.Dynamic  `tfsdk:"instance_info"`
+	DeploySteps        types.List     `tfsdk:"deploy_steps"`
+	UserData           types.Dynamic  `tfsdk:"user_data"`
+	UserDataURL        types.String   `tfsdk:"user_data_url"`
+	UserDataURLCaCert  types.String   `tfsdk:"user_data_url_ca_cert"`
+	UserDataURLHeaders types.Dynamic  `tfsdk:"user_data_url_headers"`
+	NetworkData        types.Dynamic  `tfsdk:"network_data"`
+	Metadata           types.Dynamic  `tfsdk:"metadata"`
+	FixedIPs           types.List     `tfsdk:"fixed_ips"`
+	Rescue             types.Bool     `tfsdk:"rescue"`
+	RescuePassword     types.String   `tfsdk:"rescue_password"`
+	RescueInterface    types.String   `tfsdk:"rescue_interface"`
+	ProvisionState     types.String   `tfsdk:"provision_state"`
+	LastError          types.String   `tfsdk:"last_error"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
 }
 
 func NewDeploymentResource() resource.Resource {
@@ -100,11 +114,8 @@ func (r *deploymentResource) Schema(
 				},
 			},
 			"name": schema.StringAttribute{
-				MarkdownDescription: "The name of the deployment.",
+				MarkdownDescription: "The name of the deployment, only kept in the Terraform state.",
 				Optional:            true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"node_uuid": schema.StringAttribute{
 				MarkdownDescription: "The UUID of the node to deploy.",
@@ -117,7 +128,7 @@ func (r *deploymentResource) Schema(
 				MarkdownDescription: "Instance information for the deployment.",
 				Required:            true,
 				PlanModifiers: []planmodifier.Dynamic{
-					dynamicplanmodifier.RequiresReplace(),
+					rebuildOnChange(),
 				},
 			},
 			"deploy_steps": schema.ListNestedAttribute{
@@ -157,48 +168,49 @@ func (r *deploymentResource) Schema(
 				},
 				PlanModifiers: []planmodifier.List{
 					listplanmodifier.UseStateForUnknown(),
+					rebuildOnChange(),
 				},
 			},
 			"user_data": schema.DynamicAttribute{
 				MarkdownDescription: "User data for the deployment.",
 				Optional:            true,
 				PlanModifiers: []planmodifier.Dynamic{
-					dynamicplanmodifier.RequiresReplace(),
+					rebuildOnChange(),
 				},
 			},
 			"user_data_url": schema.StringAttribute{
 				MarkdownDescription: "URL to fetch user data from.",
 				Optional:            true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					rebuildOnChange(),
 				},
 			},
 			"user_data_url_ca_cert": schema.StringAttribute{
 				MarkdownDescription: "CA certificate for user data URL verification.",
 				Optional:            true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					rebuildOnChange(),
 				},
 			},
 			"user_data_url_headers": schema.DynamicAttribute{
 				MarkdownDescription: "Headers to send when fetching user data URL.",
 				Optional:            true,
 				PlanModifiers: []planmodifier.Dynamic{
-					dynamicplanmodifier.RequiresReplace(),
+					rebuildOnChange(),
 				},
 			},
 			"network_data": schema.DynamicAttribute{
 				MarkdownDescription: "Network data for the deployment.",
 				Optional:            true,
 				PlanModifiers: []planmodifier.Dynamic{
-					dynamicplanmodifier.RequiresReplace(),
+					rebuildOnChange(),
 				},
 			},
 			"metadata": schema.DynamicAttribute{
 				MarkdownDescription: "Metadata for the deployment.",
 				Optional:            true,
 				PlanModifiers: []planmodifier.Dynamic{
-					dynamicplanmodifier.RequiresReplace(),
+					rebuildOnChange(),
 				},
 			},
 			"fixed_ips": schema.ListNestedAttribute{
@@ -212,16 +224,50 @@ func (r *deploymentResource) Schema(
 					},
 				},
 				Optional: true,
+				PlanModifiers: []planmodifier.List{
+					rebuildOnChange(),
+				},
+			},
+			"rescue": schema.BoolAttribute{
+				MarkdownDescription: "Whether the node is rescued. Setting it to true boots the node into the rescue ramdisk, and setting it back to false returns it to `active`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"rescue_password": schema.StringAttribute{
+				MarkdownDescription: "The password of the rescue user, required to rescue the node.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"rescue_interface": schema.StringAttribute{
+				MarkdownDescription: "The rescue interface to set on the node before deploying it. Ironic refuses to change it on a deployed node, so changing it replaces the deployment.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"provision_state": schema.StringAttribute{
 				MarkdownDescription: "The current provision state of the node.",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"last_error": schema.StringAttribute{
 				MarkdownDescription: "The last error message from the node.",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -249,6 +295,60 @@ func (r *deploymentResource) Configure(
 	r.meta = clients
 }
 
+// ModifyPlan reports attributes that need a newer Ironic API microversion
+// than the provider is using.
+func (r *deploymentResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	// Nothing to check on destroy, or before the provider is configured.
+	if req.Plan.Raw.IsNull() || r.meta == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(
+		r.meta.validateMicroversions(ctx, "ironic_deployment", req.Config, deploymentMicroversions)...,
+	)
+
+	var rescue types.Bool
+	var rescuePassword types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("rescue"), &rescue)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("rescue_password"), &rescuePassword)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if rescue.ValueBool() && rescuePassword.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("rescue_password"),
+			"Missing Rescue Password",
+			"rescue_password must be set when rescue is true.",
+		)
+		return
+	}
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state deploymentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The node only changes provision state when rebuilt or (un)rescued
+	reasons, diags := deploymentRebuildReasons(ctx, &plan, &state)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(validateRebuildRescue(&plan, &state, reasons)...)
+	if len(reasons) > 0 || !plan.Rescue.Equal(state.Rescue) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("provision_state"), types.StringUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("last_error"), types.StringUnknown())...)
+	}
+}
+
 func (r *deploymentResource) Create(
 	ctx context.Context,
 	req resource.CreateRequest,
@@ -261,6 +361,15 @@ func (r *deploymentResource) Create(
 		return
 	}
 
+	createTimeout, diags := model.Timeouts.Create(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := operationContext(ctx, createTimeout)
+	defer cancel()
+
 	nodeUUID := model.NodeUUID.ValueString()
 	model.ID = model.NodeUUID
 
@@ -289,25 +398,10 @@ func (r *deploymentResource) Create(
 	// Prepare update options
 	updateOpts := nodes.UpdateOpts{}
 
-	// Handle deploy_steps if present
-	var deploySteps []nodes.DeployStep
-	if !model.DeploySteps.IsNull() && !model.DeploySteps.IsUnknown() {
-		var dSteps []deployStepModel
-		resp.Diagnostics.Append(model.DeploySteps.ElementsAs(ctx, &dSteps, false)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		resp.Diagnostics.Append(buildDeploySteps(ctx, dSteps, &deploySteps)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		if len(deploySteps) > 0 {
-			updateOpts = append(updateOpts, nodes.UpdateOperation{
-				Op:    nodes.AddOp,
-				Path:  "/deploy_steps",
-				Value: deploySteps,
-			})
-		}
+	// Deploy steps are passed with the provision state change
+	deploySteps := r.deploySteps(ctx, &model, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	util.AddDynamicUpdateOptForFieldWithMap(
@@ -320,55 +414,99 @@ func (r *deploymentResource) Create(
 	)
 
 	// Handle fixed_ips if present
-	if !model.FixedIPs.IsNull() && !model.FixedIPs.IsUnknown() {
-		var fixedIPs []fixedIpModel
-		resp.Diagnostics.Append(model.FixedIPs.ElementsAs(ctx, &fixedIPs, false)...)
-		if resp.Diagnostics.HasError() {
+	fixedIPs := fixedIPsValue(ctx, model.FixedIPs, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(fixedIPs) > 0 {
+		updateOpts = append(updateOpts, nodes.UpdateOperation{
+			Op:    nodes.AddOp,
+			Path:  "/instance_info/fixed_ips",
+			Value: fixedIPs,
+		})
+	}
+
+	if !model.RescueInterface.IsNull() {
+		updateOpts = append(updateOpts, nodeFieldUpdateOp("rescue_interface", model.RescueInterface))
+	}
+
+	if len(updateOpts) > 0 {
+		_, err = UpdateNode(ctx, r.meta.Client, nodeUUID, updateOpts)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating node",
+				fmt.Sprintf("Could not update node %s for deployment: %s", nodeUUID, err),
+			)
 			return
 		}
+	}
 
-		if len(fixedIPs) > 0 {
-			// Convert to interface{} slice for the update operation
-			fixedIPsInterface := make([]any, len(fixedIPs))
-			for i, ip := range fixedIPs {
-				ipMap := make(map[string]any)
-				ipMap["ip_address"] = ip.IPAddress.ValueString()
-				fixedIPsInterface[i] = ipMap
-			}
+	configDrive := r.configDrive(ctx, &model, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-			updateOpts = append(updateOpts, nodes.UpdateOperation{
-				Op:    nodes.AddOp,
-				Path:  "/instance_info/fixed_ips",
-				Value: fixedIPsInterface,
-			})
+	// Deploy the node - drive Ironic state machine until node is 'active'
+	tflog.Info(ctx, "Starting deployment", map[string]any{
+		"node_uuid": nodeUUID,
+		"target":    "active",
+	})
 
-			_, err = UpdateNode(ctx, r.meta.Client, nodeUUID, updateOpts)
-			if err != nil {
-				resp.Diagnostics.AddError(
-					"Error updating fixed_ips",
-					fmt.Sprintf("Could not update fixed_ips: %s", err),
-				)
-				return
-			}
+	if err := ChangeProvisionStateToTarget(
+		ctx,
+		r.meta.Client,
+		nodeUUID,
+		nodes.TargetActive,
+		configDrive,
+		deploySteps,
+		nil,
+		nil, // serviceSteps
+	); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deploying node",
+			fmt.Sprintf("Could not deploy node: %s", err),
+		)
+		return
+	}
+
+	if model.Rescue.ValueBool() {
+		r.changeRescue(ctx, &model, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
 		}
 	}
 
+	// Read the final state
+	r.read(ctx, &model, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+// configDrive builds the config drive of the deployment from the user data,
+// network data and metadata.
+func (r *deploymentResource) configDrive(
+	ctx context.Context,
+	model *deploymentResourceModel,
+	diagnostics *diag.Diagnostics,
+) any {
 	// Handle user data
 	userDataURL := model.UserDataURL.ValueString()
 	userDataCaCert := model.UserDataURLCaCert.ValueString()
 
-	// Build config drive
 	var userDataMap map[string]any
 	if !model.UserData.IsNull() && !model.UserData.IsUnknown() {
 		var err error
 		userDataMap, err = util.DynamicToMap(ctx, model.UserData)
 		if err != nil {
-			resp.Diagnostics.AddAttributeError(
+			diagnostics.AddAttributeError(
 				path.Root("user_data"),
 				"Error Converting Network Data",
 				fmt.Sprintf("Could not convert user_data to map: %s", err),
 			)
-			return
+			return nil
 		}
 	}
 
@@ -376,12 +514,12 @@ func (r *deploymentResource) Create(
 	if !model.UserDataURLHeaders.IsNull() && !model.UserDataURLHeaders.IsUnknown() {
 		userDataHeadersMap, err := util.DynamicToMap(ctx, model.UserDataURLHeaders)
 		if err != nil {
-			resp.Diagnostics.AddAttributeError(
+			diagnostics.AddAttributeError(
 				path.Root("user_data_url_headers"),
 				"Error Converting Headers",
 				fmt.Sprintf("Could not convert user_data_url_headers to map: %s", err),
 			)
-			return
+			return nil
 		}
 		if userDataHeadersMap != nil {
 			userDataHeaders = userDataHeadersMap
@@ -391,11 +529,11 @@ func (r *deploymentResource) Create(
 	// If user_data_url is specified in addition to user_data, use the former
 	ignitionData, err := fetchFullIgnition(userDataURL, userDataCaCert, userDataHeaders)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		diagnostics.AddError(
 			"Error fetching user data from URL",
 			fmt.Sprintf("Could not fetch data from user_data_url: %s", err),
 		)
-		return
+		return nil
 	}
 	if ignitionData != nil {
 		userDataMap = ignitionData
@@ -407,12 +545,12 @@ func (r *deploymentResource) Create(
 		var err error
 		networkDataMap, err = util.DynamicToMap(ctx, model.NetworkData)
 		if err != nil {
-			resp.Diagnostics.AddAttributeError(
+			diagnostics.AddAttributeError(
 				path.Root("network_data"),
 				"Error Converting Network Data",
 				fmt.Sprintf("Could not convert network_data to map: %s", err),
 			)
-			return
+			return nil
 		}
 	}
 
@@ -421,12 +559,12 @@ func (r *deploymentResource) Create(
 		var err error
 		metaDataMap, err = util.DynamicToMap(ctx, model.Metadata)
 		if err != nil {
-			resp.Diagnostics.AddAttributeError(
+			diagnostics.AddAttributeError(
 				path.Root("metadata"),
 				"Error Converting Metadata",
 				fmt.Sprintf("Could not convert metadata to map: %s", err),
 			)
-			return
+			return nil
 		}
 	}
 
@@ -437,43 +575,56 @@ func (r *deploymentResource) Create(
 		metaDataMap,
 	)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		diagnostics.AddError(
 			"Error building config drive",
 			fmt.Sprintf("Could not build config drive: %s", err),
 		)
-		return
+		return nil
 	}
+	return configDrive
+}
 
-	// Deploy the node - drive Ironic state machine until node is 'active'
-	tflog.Info(ctx, "Starting deployment", map[string]any{
-		"node_uuid": nodeUUID,
-		"target":    "active",
-	})
+// deploySteps converts the deploy_steps attribute, if set.
+func (r *deploymentResource) deploySteps(
+	ctx context.Context,
+	model *deploymentResourceModel,
+	diagnostics *diag.Diagnostics,
+) []nodes.DeployStep {
+	if model.DeploySteps.IsNull() || model.DeploySteps.IsUnknown() {
+		return nil
+	}
 
-	if err := ChangeProvisionStateToTarget(
-		ctx,
-		r.meta.Client,
-		nodeUUID,
-		nodes.TargetActive,
-		configDrive,
-		deploySteps,
-		nil,
-		nil, // serviceSteps
-	); err != nil {
-		resp.Diagnostics.AddError(
-			"Error deploying node",
-			fmt.Sprintf("Could not deploy node: %s", err),
-		)
-		return
+	var dSteps []deployStepModel
+	diagnostics.Append(model.DeploySteps.ElementsAs(ctx, &dSteps, false)...)
+	if diagnostics.HasError() {
+		return nil
 	}
 
-	// Read the final state
-	r.read(ctx, &model, &resp.Diagnostics)
-	if resp.Diagnostics.HasError() {
-		return
+	var deploySteps []nodes.DeployStep
+	diagnostics.Append(buildDeploySteps(ctx, dSteps, &deploySteps)...)
+	return deploySteps
+}
+
+// fixedIPsValue converts the fixed_ips attribute to its instance_info format.
+func fixedIPsValue(ctx context.Context, list types.List, diagnostics *diag.Diagnostics) []any {
+	if list.IsNull() || list.IsUnknown() {
+		return nil
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+	var fixedIPs []fixedIpModel
+	diagnostics.Append(list.ElementsAs(ctx, &fixedIPs, false)...)
+	if diagnostics.HasError() {
+		return nil
+	}
+
+	// Convert to interface{} slice for the update operation
+	fixedIPsInterface := make([]any, len(fixedIPs))
+	for i, ip := range fixedIPs {
+		ipMap := make(map[string]any)
+		ipMap["ip_address"] = ip.IPAddress.ValueString()
+		fixedIPsInterface[i] = ipMap
+	}
+	return fixedIPsInterface
 }
 
 func (r *deploymentResource) Read(
@@ -539,6 +690,41 @@ func (r *deploymentResource) read(
 
 	model.ProvisionState = types.StringValue(result.ProvisionState)
 	model.LastError = types.StringValue(result.LastError)
+	model.Rescue = types.BoolValue(result.ProvisionState == string(nodes.Rescue))
+}
+
+// changeRescue rescues or unrescues the node to match the rescue attribute.
+func (r *deploymentResource) changeRescue(
+	ctx context.Context,
+	model *deploymentResourceModel,
+	diagnostics *diag.Diagnostics,
+) {
+	nodeUUID := model.ID.ValueString()
+
+	if !model.Rescue.ValueBool() {
+		err := ChangeProvisionStateToTarget(ctx, r.meta.Client, nodeUUID, nodes.TargetUnrescue, nil, nil, nil, nil)
+		if err != nil {
+			diagnostics.AddError(
+				"Error unrescuing node",
+				fmt.Sprintf("Could not unrescue node %s: %s", nodeUUID, err),
+			)
+		}
+		return
+	}
+
+	err := runProvisionWorkflow(&provisionWorkflow{
+		ctx:            ctx,
+		client:         r.meta.Client,
+		nodeID:         nodeUUID,
+		target:         nodes.TargetRescue,
+		rescuePassword: model.RescuePassword.ValueString(),
+	})
+	if err != nil {
+		diagnostics.AddError(
+			"Error rescuing node",
+			fmt.Sprintf("Could not rescue node %s: %s", nodeUUID, err),
+		)
+	}
 }
 
 func (r *deploymentResource) Update(
@@ -546,11 +732,168 @@ func (r *deploymentResource) Update(
 	req resource.UpdateRequest,
 	resp *resource.UpdateResponse,
 ) {
-	// Deployment updates are not supported - all attributes require replacement
-	resp.Diagnostics.AddError(
-		"Update not supported",
-		"Updates to deployment resources are not supported. All changes require replacement.",
+	var plan, state deploymentResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := operationContext(ctx, updateTimeout)
+	defer cancel()
+
+	nodeUUID := state.ID.ValueString()
+
+	reasons, diags := deploymentRebuildReasons(ctx, &plan, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Timeouts = plan.Timeouts
+	state.RescuePassword = plan.RescuePassword
+
+	// Only active nodes can be rebuilt, unrescue first
+	if state.Rescue.ValueBool() && !plan.Rescue.ValueBool() {
+		state.Rescue = plan.Rescue
+		r.changeRescue(ctx, &state, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			r.read(ctx, &state, &resp.Diagnostics)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+			return
+		}
+	}
+
+	// Patch the node first, a rebuild picks up the new instance_info
+	updateOpts := nodes.UpdateOpts{}
+	util.AddDynamicUpdateOpsForField(
+		ctx,
+		&updateOpts,
+		&resp.Diagnostics,
+		plan.InstanceInfo,
+		state.InstanceInfo,
+		"instance_info",
+	)
+	if !plan.FixedIPs.Equal(state.FixedIPs) {
+		if fixedIPs := fixedIPsValue(ctx, plan.FixedIPs, &resp.Diagnostics); len(fixedIPs) > 0 {
+			updateOpts = append(updateOpts, nodes.UpdateOperation{
+				Op:    nodes.AddOp,
+				Path:  "/instance_info/fixed_ips",
+				Value: fixedIPs,
+			})
+		} else {
+			updateOpts = append(updateOpts, nodes.UpdateOperation{
+				Op:   nodes.RemoveOp,
+				Path: "/instance_info/fixed_ips",
+			})
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(updateOpts) > 0 {
+		if _, err := UpdateNode(ctx, r.meta.Client, nodeUUID, updateOpts); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating deployment",
+				fmt.Sprintf("Could not update node %s: %s", nodeUUID, err),
+			)
+			r.read(ctx, &state, &resp.Diagnostics)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+			return
+		}
+	}
+
+	state.Name = plan.Name
+
+	// Without a successful rebuild the prior values are kept, so the next
+	// apply tries again
+	if len(reasons) > 0 {
+		r.rebuild(ctx, &plan, reasons, &resp.Diagnostics)
+	}
+	if !resp.Diagnostics.HasError() {
+		state.InstanceInfo = plan.InstanceInfo
+		state.DeploySteps = plan.DeploySteps
+		state.UserData = plan.UserData
+		state.UserDataURL = plan.UserDataURL
+		state.UserDataURLCaCert = plan.UserDataURLCaCert
+		state.UserDataURLHeaders = plan.UserDataURLHeaders
+		state.NetworkData = plan.NetworkData
+		state.Metadata = plan.Metadata
+		state.FixedIPs = plan.FixedIPs
+
+		// Rescue once rebuilt
+		if plan.Rescue.ValueBool() && !state.Rescue.ValueBool() {
+			state.Rescue = plan.Rescue
+			r.changeRescue(ctx, &state, &resp.Diagnostics)
+		}
+	}
+
+	// Save the node state even when rebuilding or rescuing failed
+	r.read(ctx, &state, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// rebuild deploys the node again with the deploy steps and a new config
+// drive.
+func (r *deploymentResource) rebuild(
+	ctx context.Context,
+	model *deploymentResourceModel,
+	reasons []string,
+	diagnostics *diag.Diagnostics,
+) {
+	nodeUUID := model.ID.ValueString()
+
+	node, err := nodes.Get(ctx, r.meta.Client, nodeUUID).Extract()
+	if err != nil {
+		diagnostics.AddError(
+			"Error getting node",
+			fmt.Sprintf("Could not get node %s: %s", nodeUUID, err),
+		)
+		return
+	}
+	if node.ProvisionState != string(nodes.Active) {
+		diagnostics.AddError(
+			"Invalid Node State for Rebuild",
+			fmt.Sprintf("Only active deployments can be rebuilt, but node %s is %s.", nodeUUID, node.ProvisionState),
+		)
+		return
+	}
+
+	deploySteps := r.deploySteps(ctx, model, diagnostics)
+	configDrive := r.configDrive(ctx, model, diagnostics)
+	if diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Rebuilding deployment", map[string]any{
+		"node_uuid": nodeUUID,
+		"changed":   reasons,
+	})
+
+	err = ChangeProvisionStateToTarget(
+		ctx,
+		r.meta.Client,
+		nodeUUID,
+		nodes.TargetRebuild,
+		configDrive,
+		deploySteps,
+		nil,
+		nil, // serviceSteps
 	)
+	if err != nil {
+		diagnostics.AddError(
+			"Error rebuilding deployment",
+			fmt.Sprintf("Could not rebuild node %s: %s", nodeUUID, err),
+		)
+	}
 }
 
 func (r *deploymentResource) Delete(
@@ -565,6 +908,15 @@ func (r *deploymentResource) Delete(
 		return
 	}
 
+	deleteTimeout, diags := model.Timeouts.Delete(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := operationContext(ctx, deleteTimeout)
+	defer cancel()
+
 	nodeUUID := model.ID.ValueString()
 
 	tflog.Info(ctx, "Deleting deployment", map[string]any{
@@ -0,0 +1,256 @@
+package ironic
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/drivers"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &DriverDataSource{}
+	_ datasource.DataSourceWithConfigure = &DriverDataSource{}
+)
+
+// driverMicroversions lists the microversions needed by the driver data
+// sources, the interfaces of hardware types appeared in 1.30.
+var driverMicroversions = []microversionRequirement{
+	{Version: "1.30"},
+}
+
+// DriverDataSource describes an Ironic driver and its properties.
+type DriverDataSource struct {
+	meta *Meta
+}
+
+// driverModel describes a driver.
+type driverModel struct {
+	Name               types.String        `tfsdk:"name"`
+	Type               types.String        `tfsdk:"type"`
+	Hosts              []string            `tfsdk:"hosts"`
+	DefaultInterfaces  map[string]string   `tfsdk:"default_interfaces"`
+	EnabledInterfaces  map[string][]string `tfsdk:"enabled_interfaces"`
+	Properties         map[string]string   `tfsdk:"properties"`
+	RequiredProperties []string            `tfsdk:"required_properties"`
+	OptionalProperties []string            `tfsdk:"optional_properties"`
+}
+
+// driverDataSourceModel describes the data source data model.
+type driverDataSourceModel struct {
+	driverModel
+	ID types.String `tfsdk:"id"`
+}
+
+func NewDriverDataSource() datasource.DataSource {
+	return &DriverDataSource{}
+}
+
+func (d *DriverDataSource) Metadata(
+	ctx context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_driver"
+}
+
+func (d *DriverDataSource) Schema(
+	ctx context.Context,
+	req datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	attributes := driverAttributes()
+	attributes["name"] = schema.StringAttribute{
+		MarkdownDescription: "The name of the driver, such as `redfish` or `ipmi`.",
+		Required:            true,
+	}
+	attributes["id"] = schema.StringAttribute{
+		MarkdownDescription: "The name of the driver.",
+		Computed:            true,
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Describes an Ironic driver (hardware type): its enabled and default interfaces, and the `driver_info` properties it accepts.",
+		Attributes:          attributes,
+	}
+}
+
+// driverAttributes returns the computed attributes describing a driver.
+func driverAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"name": schema.StringAttribute{
+			MarkdownDescription: "The name of the driver.",
+			Computed:            true,
+		},
+		"type": schema.StringAttribute{
+			MarkdownDescription: "The type of the driver, `dynamic` for hardware types.",
+			Computed:            true,
+		},
+		"hosts": schema.ListAttribute{
+			MarkdownDescription: "The conductors the driver is enabled on.",
+			ElementType:         types.StringType,
+			Computed:            true,
+		},
+		"default_interfaces": schema.MapAttribute{
+			MarkdownDescription: "The interface used by default for each kind of interface, such as `boot` or `power`.",
+			ElementType:         types.StringType,
+			Computed:            true,
+		},
+		"enabled_interfaces": schema.MapAttribute{
+			MarkdownDescription: "The interfaces enabled for each kind of interface, such as `boot` or `power`.",
+			ElementType:         types.ListType{ElemType: types.StringType},
+			Computed:            true,
+		},
+		"properties": schema.MapAttribute{
+			MarkdownDescription: "The `driver_info` properties accepted by the driver, with their description.",
+			ElementType:         types.StringType,
+			Computed:            true,
+		},
+		"required_properties": schema.ListAttribute{
+			MarkdownDescription: "The `driver_info` properties the driver requires, sorted.",
+			ElementType:         types.StringType,
+			Computed:            true,
+		},
+		"optional_properties": schema.ListAttribute{
+			MarkdownDescription: "The optional `driver_info` properties of the driver, sorted.",
+			ElementType:         types.StringType,
+			Computed:            true,
+		},
+	}
+}
+
+func (d *DriverDataSource) Configure(
+	ctx context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*Meta)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf(
+				"Expected *Clients, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+		return
+	}
+
+	d.meta = clients
+}
+
+func (d *DriverDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var name types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("name"), &name)...)
+	resp.Diagnostics.Append(
+		d.meta.validateMicroversions(ctx, "ironic_driver", req.Config, driverMicroversions)...,
+	)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	driver, err := drivers.GetDriverDetails(ctx, d.meta.Client, name.ValueString()).Extract()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Driver",
+			fmt.Sprintf("Could not read driver %s: %s", name.ValueString(), err),
+		)
+		return
+	}
+
+	model, err := readDriver(ctx, d.meta.Client, *driver)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Driver",
+			fmt.Sprintf("Could not read the properties of driver %s: %s", name.ValueString(), err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, driverDataSourceModel{
+		driverModel: model,
+		ID:          types.StringValue(driver.Name),
+	})...)
+}
+
+// readDriver describes a driver, including its properties.
+func readDriver(
+	ctx context.Context,
+	client *gophercloud.ServiceClient,
+	driver drivers.Driver,
+) (driverModel, error) {
+	model := driverModel{
+		Name:               types.StringValue(driver.Name),
+		Type:               types.StringValue(driver.Type),
+		Hosts:              append([]string{}, driver.Hosts...),
+		DefaultInterfaces:  map[string]string{},
+		EnabledInterfaces:  map[string][]string{},
+		Properties:         map[string]string{},
+		RequiredProperties: []string{},
+		OptionalProperties: []string{},
+	}
+
+	for kind, iface := range map[string]struct {
+		defaultInterface  string
+		enabledInterfaces []string
+	}{
+		"bios":       {driver.DefaultBiosInterface, driver.EnabledBiosInterfaces},
+		"boot":       {driver.DefaultBootInterface, driver.EnabledBootInterfaces},
+		"console":    {driver.DefaultConsoleInterface, driver.EnabledConsoleInterface},
+		"deploy":     {driver.DefaultDeployInterface, driver.EnabledDeployInterfaces},
+		"firmware":   {driver.DefaultFirmwareInterface, driver.EnabledFirmwareInterfaces},
+		"inspect":    {driver.DefaultInspectInterface, driver.EnabledInspectInterfaces},
+		"management": {driver.DefaultManagementInterface, driver.EnabledManagementInterfaces},
+		"network":    {driver.DefaultNetworkInterface, driver.EnabledNetworkInterfaces},
+		"power":      {driver.DefaultPowerInterface, driver.EnabledPowerInterfaces},
+		"raid":       {driver.DefaultRaidInterface, driver.EnabledRaidInterfaces},
+		"rescue":     {driver.DefaultRescueInterface, driver.EnabledRescueInterfaces},
+		"storage":    {driver.DefaultStorageInterface, driver.EnabledStorageInterfaces},
+		"vendor":     {driver.DefaultVendorInterface, driver.EnabledVendorInterfaces},
+	} {
+		// Interfaces newer than the microversion are not returned
+		if iface.defaultInterface == "" && len(iface.enabledInterfaces) == 0 {
+			continue
+		}
+		model.DefaultInterfaces[kind] = iface.defaultInterface
+		model.EnabledInterfaces[kind] = append([]string{}, iface.enabledInterfaces...)
+	}
+
+	properties, err := drivers.GetDriverProperties(ctx, client, driver.Name).Extract()
+	if err != nil {
+		return model, err
+	}
+	for _, property := range slices.Sorted(maps.Keys(*properties)) {
+		description := fmt.Sprint((*properties)[property])
+		model.Properties[property] = description
+		if driverPropertyRequired(description) {
+			model.RequiredProperties = append(model.RequiredProperties, property)
+		} else {
+			model.OptionalProperties = append(model.OptionalProperties, property)
+		}
+	}
+
+	return model, nil
+}
+
+// driverPropertyRequired tells whether a driver property is required, Ironic
+// ends the description of required properties with "Required.".
+func driverPropertyRequired(description string) bool {
+	return strings.HasSuffix(strings.TrimSpace(description), "Required.")
+}
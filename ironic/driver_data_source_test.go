@@ -0,0 +1,106 @@
+package ironic
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/noauth"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	th "github.com/metal3-community/terraform-provider-ironic/testhelper"
+)
+
+func TestDriverDataSourcesRead(t *testing.T) {
+	redfish := map[string]any{
+		"name":                     "redfish",
+		"type":                     "dynamic",
+		"hosts":                    []string{"conductor-0"},
+		"default_boot_interface":   "redfish-virtual-media",
+		"enabled_boot_interfaces":  []string{"ipxe", "redfish-virtual-media"},
+		"default_power_interface":  "redfish",
+		"enabled_power_interfaces": []string{"redfish"},
+	}
+
+	var listQuery string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/drivers", func(w http.ResponseWriter, r *http.Request) {
+		listQuery = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"drivers": []any{redfish}})
+	})
+	mux.HandleFunc("/v1/drivers/redfish", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(redfish)
+	})
+	mux.HandleFunc("/v1/drivers/redfish/properties", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"redfish_address":  "The URL address to the Redfish controller. Required.",
+			"redfish_username": "User account with admin/server-profile access privilege. Optional.",
+			"redfish_password": "User account password. Optional.",
+		})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := noauth.NewBareMetalNoAuth(noauth.EndpointOpts{
+		IronicEndpoint: server.URL + "/v1",
+	})
+	th.AssertNoError(t, err)
+	meta := &Meta{Client: client}
+	ctx := context.Background()
+
+	checkDriver := func(t *testing.T, driver driverModel) {
+		if driver.Name.ValueString() != "redfish" || driver.Type.ValueString() != "dynamic" {
+			t.Errorf("unexpected driver: %v", driver)
+		}
+		if !reflect.DeepEqual(driver.DefaultInterfaces, map[string]string{
+			"boot":  "redfish-virtual-media",
+			"power": "redfish",
+		}) {
+			t.Errorf("unexpected default interfaces: %v", driver.DefaultInterfaces)
+		}
+		if !reflect.DeepEqual(driver.EnabledInterfaces["boot"], []string{"ipxe", "redfish-virtual-media"}) {
+			t.Errorf("unexpected enabled interfaces: %v", driver.EnabledInterfaces)
+		}
+		if !reflect.DeepEqual(driver.RequiredProperties, []string{"redfish_address"}) {
+			t.Errorf("unexpected required properties: %v", driver.RequiredProperties)
+		}
+		if !reflect.DeepEqual(driver.OptionalProperties, []string{"redfish_password", "redfish_username"}) {
+			t.Errorf("unexpected optional properties: %v", driver.OptionalProperties)
+		}
+	}
+
+	t.Run("driver", func(t *testing.T) {
+		resp := readDataSource(t, &DriverDataSource{meta: meta}, map[string]tftypes.Value{
+			"name": tftypes.NewValue(tftypes.String, "redfish"),
+		})
+		var state driverDataSourceModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+		}
+		checkDriver(t, state.driverModel)
+	})
+
+	t.Run("drivers", func(t *testing.T) {
+		resp := readDataSource(t, &DriversDataSource{meta: meta}, map[string]tftypes.Value{
+			"type": tftypes.NewValue(tftypes.String, "dynamic"),
+		})
+		var state driversDataSourceModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+		}
+		if listQuery != "detail=true&type=dynamic" {
+			t.Errorf("unexpected query %q", listQuery)
+		}
+		if len(state.Drivers) != 1 {
+			t.Fatalf("expected 1 driver, got %v", state.Drivers)
+		}
+		checkDriver(t, state.Drivers[0])
+	})
+}
@@ -0,0 +1,199 @@
+package ironic
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/drivers"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// conductorDefaultProperties are driver_info properties Ironic documents as
+// required, but which the conductor configuration can provide.
+var conductorDefaultProperties = []string{
+	"bootloader",
+	"deploy_kernel",
+	"deploy_ramdisk",
+	"rescue_kernel",
+	"rescue_ramdisk",
+}
+
+// driver returns the description of a driver, read once per provider.
+func (m *Meta) driver(ctx context.Context, name string) (driverModel, error) {
+	m.driversMu.Lock()
+	defer m.driversMu.Unlock()
+
+	if driver, ok := m.drivers[name]; ok {
+		return driver, nil
+	}
+
+	details, err := drivers.GetDriverDetails(ctx, m.Client, name).Extract()
+	if err != nil {
+		return driverModel{}, err
+	}
+	driver, err := readDriver(ctx, m.Client, *details)
+	if err != nil {
+		return driverModel{}, err
+	}
+
+	if m.drivers == nil {
+		m.drivers = map[string]driverModel{}
+	}
+	m.drivers[name] = driver
+	return driver, nil
+}
+
+// validateDriverInfo checks the configured driver_info of a node against the
+// properties of its driver, when the driver or driver_info change.
+func (r *NodeResource) validateDriverInfo(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var config NodeResourceModel
+	diags.Append(req.Config.Get(ctx, &config)...)
+	if diags.HasError() || config.Driver.IsUnknown() {
+		return diags
+	}
+
+	keys, ok := driverInfoKeys(config.DriverInfo)
+	if !ok {
+		return diags
+	}
+
+	if !req.State.Raw.IsNull() {
+		var driver types.String
+		var driverInfo types.Dynamic
+		diags.Append(req.State.GetAttribute(ctx, path.Root("driver"), &driver)...)
+		diags.Append(req.State.GetAttribute(ctx, path.Root("driver_info"), &driverInfo)...)
+		if diags.HasError() || (config.Driver.Equal(driver) && config.DriverInfo.Equal(driverInfo)) {
+			return diags
+		}
+	}
+
+	driver, err := r.meta.driver(ctx, config.Driver.ValueString())
+	if err != nil {
+		diags.AddAttributeWarning(
+			path.Root("driver_info"),
+			"Unable to Validate driver_info",
+			fmt.Sprintf("Could not read driver %s: %s", config.Driver.ValueString(), err),
+		)
+		return diags
+	}
+
+	diags.Append(checkDriverInfo(driver, keys, nodeInterfaceFields(&config))...)
+	return diags
+}
+
+// driverInfoKeys returns the sorted top level keys of driver_info, false when
+// it is not set or not known yet.
+func driverInfoKeys(driverInfo types.Dynamic) ([]string, bool) {
+	if driverInfo.IsNull() || driverInfo.IsUnknown() ||
+		driverInfo.IsUnderlyingValueNull() || driverInfo.IsUnderlyingValueUnknown() {
+		return nil, false
+	}
+
+	switch value := driverInfo.UnderlyingValue().(type) {
+	case types.Object:
+		return slices.Sorted(maps.Keys(value.Attributes())), true
+	case types.Map:
+		return slices.Sorted(maps.Keys(value.Elements())), true
+	}
+	return nil, false
+}
+
+// checkDriverInfo warns about driver_info keys the driver does not know, and
+// reports the required properties of the driver missing from keys. Ironic
+// only describes the properties of the default interfaces of a driver, so
+// missing properties are only warnings when other interfaces are configured.
+func checkDriverInfo(
+	driver driverModel,
+	keys []string,
+	interfaces map[string]types.String,
+) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var custom []string
+	for field, value := range interfaces {
+		kind := strings.TrimSuffix(field, "_interface")
+		if value.IsUnknown() || (!value.IsNull() && value.ValueString() != driver.DefaultInterfaces[kind]) {
+			custom = append(custom, field)
+		}
+	}
+	slices.Sort(custom)
+
+	note := ""
+	if len(custom) > 0 {
+		note = fmt.Sprintf(
+			"\n\nIronic only describes the properties of the default interfaces of the driver, "+
+				"the configured %s may differ.", strings.Join(custom, ", "))
+	}
+
+	for _, key := range keys {
+		if _, ok := driver.Properties[key]; ok {
+			continue
+		}
+		detail := fmt.Sprintf("The %s driver does not know the driver_info property %q.",
+			driver.Name.ValueString(), key)
+		if suggestion := closestProperty(key, driver.Properties); suggestion != "" {
+			detail += fmt.Sprintf(" Did you mean %q?", suggestion)
+		}
+		diags.AddAttributeWarning(path.Root("driver_info"), "Unknown driver_info Property", detail+note)
+	}
+
+	for _, property := range driver.RequiredProperties {
+		if slices.Contains(keys, property) || slices.Contains(conductorDefaultProperties, property) {
+			continue
+		}
+		summary := "Missing driver_info Property"
+		detail := fmt.Sprintf("The %s driver requires the driver_info property %q: %s",
+			driver.Name.ValueString(), property, driver.Properties[property])
+		if note != "" {
+			diags.AddAttributeWarning(path.Root("driver_info"), summary, detail+note)
+		} else {
+			diags.AddAttributeError(path.Root("driver_info"), summary, detail)
+		}
+	}
+
+	return diags
+}
+
+// closestProperty returns the property closest to key when it is likely a
+// typo, or an empty string.
+func closestProperty(key string, properties map[string]string) string {
+	closest, best := "", 3
+	for _, property := range slices.Sorted(maps.Keys(properties)) {
+		if distance := editDistance(key, property); distance < best {
+			closest, best = property, distance
+		}
+	}
+	return closest
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
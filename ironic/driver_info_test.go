@@ -0,0 +1,147 @@
+package ironic
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/noauth"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	th "github.com/metal3-community/terraform-provider-ironic/testhelper"
+)
+
+func TestCheckDriverInfo(t *testing.T) {
+	redfish := driverModel{
+		Name:              types.StringValue("redfish"),
+		DefaultInterfaces: map[string]string{"boot": "redfish-virtual-media", "power": "redfish"},
+		Properties: map[string]string{
+			"redfish_address":  "The URL address to the Redfish controller. Required.",
+			"redfish_username": "User account with admin/server-profile access privilege. Optional.",
+			"deploy_kernel":    "URL or Glance UUID of the deployment kernel. Required.",
+		},
+		RequiredProperties: []string{"deploy_kernel", "redfish_address"},
+		OptionalProperties: []string{"redfish_username"},
+	}
+
+	tests := []struct {
+		name       string
+		keys       []string
+		interfaces map[string]types.String
+		errors     []string
+		warnings   []string
+	}{
+		{
+			name: "valid",
+			keys: []string{"redfish_address", "redfish_username"},
+		},
+		{
+			name:     "typo",
+			keys:     []string{"redfish_adress"},
+			errors:   []string{`requires the driver_info property "redfish_address"`},
+			warnings: []string{`does not know the driver_info property "redfish_adress". Did you mean "redfish_address"?`},
+		},
+		{
+			name:     "unknown",
+			keys:     []string{"redfish_address", "ipmi_terminal_port"},
+			warnings: []string{`does not know the driver_info property "ipmi_terminal_port".`},
+		},
+		{
+			name: "default interface",
+			keys: []string{},
+			interfaces: map[string]types.String{
+				"boot_interface": types.StringValue("redfish-virtual-media"),
+			},
+			errors: []string{`requires the driver_info property "redfish_address"`},
+		},
+		{
+			name: "other interface",
+			keys: []string{},
+			interfaces: map[string]types.String{
+				"boot_interface": types.StringValue("ipxe"),
+			},
+			warnings: []string{"the configured boot_interface may differ"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			diags := checkDriverInfo(redfish, test.keys, test.interfaces)
+
+			for kind, expected := range map[string][]string{"error": test.errors, "warning": test.warnings} {
+				found := diags.Errors()
+				if kind == "warning" {
+					found = diags.Warnings()
+				}
+				if len(found) != len(expected) {
+					t.Fatalf("expected %d %ss, got %v", len(expected), kind, found)
+				}
+				for i, detail := range expected {
+					if !strings.Contains(found[i].Detail(), detail) {
+						t.Errorf("expected %s containing %q, got %q", kind, detail, found[i].Detail())
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestDriverInfoKeys(t *testing.T) {
+	object := types.DynamicValue(types.ObjectValueMust(
+		map[string]attr.Type{"redfish_address": types.StringType, "redfish_username": types.StringType},
+		map[string]attr.Value{
+			"redfish_address":  types.StringUnknown(),
+			"redfish_username": types.StringValue("admin"),
+		},
+	))
+	keys, ok := driverInfoKeys(object)
+	if !ok || strings.Join(keys, ",") != "redfish_address,redfish_username" {
+		t.Errorf("unexpected keys %v", keys)
+	}
+
+	for _, value := range []types.Dynamic{types.DynamicNull(), types.DynamicUnknown()} {
+		if _, ok := driverInfoKeys(value); ok {
+			t.Errorf("expected no keys for %v", value)
+		}
+	}
+}
+
+func TestMetaDriverCache(t *testing.T) {
+	requests := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/drivers/redfish", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"name": "redfish", "type": "dynamic"})
+	})
+	mux.HandleFunc("/v1/drivers/redfish/properties", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"redfish_address": "Required."})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := noauth.NewBareMetalNoAuth(noauth.EndpointOpts{
+		IronicEndpoint: server.URL + "/v1",
+	})
+	th.AssertNoError(t, err)
+	meta := &Meta{Client: client}
+
+	for range 3 {
+		driver, err := meta.driver(context.Background(), "redfish")
+		th.AssertNoError(t, err)
+		if strings.Join(driver.RequiredProperties, ",") != "redfish_address" {
+			t.Errorf("unexpected required properties %v", driver.RequiredProperties)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("expected the driver to be read once, got %d requests", requests)
+	}
+
+	_, err = meta.driver(context.Background(), "ipmi")
+	th.AssertError(t, err, "404")
+}
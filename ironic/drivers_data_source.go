@@ -0,0 +1,146 @@
+package ironic
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/drivers"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &DriversDataSource{}
+	_ datasource.DataSourceWithConfigure = &DriversDataSource{}
+)
+
+// DriversDataSource lists the Ironic drivers and their properties.
+type DriversDataSource struct {
+	meta *Meta
+}
+
+// driversDataSourceModel describes the data source data model.
+type driversDataSourceModel struct {
+	ID      types.String  `tfsdk:"id"`
+	Type    types.String  `tfsdk:"type"`
+	Drivers []driverModel `tfsdk:"drivers"`
+}
+
+func NewDriversDataSource() datasource.DataSource {
+	return &DriversDataSource{}
+}
+
+func (d *DriversDataSource) Metadata(
+	ctx context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_drivers"
+}
+
+func (d *DriversDataSource) Schema(
+	ctx context.Context,
+	req datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the Ironic drivers (hardware types) enabled on the conductors, with their interfaces and the `driver_info` properties they accept.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Data source identifier.",
+				Computed:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Only list drivers of this type, `classic` or `dynamic`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("classic", "dynamic"),
+				},
+			},
+			"drivers": schema.ListNestedAttribute{
+				MarkdownDescription: "The drivers, in the order returned by Ironic.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: driverAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func (d *DriversDataSource) Configure(
+	ctx context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*Meta)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf(
+				"Expected *Clients, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+		return
+	}
+
+	d.meta = clients
+}
+
+func (d *DriversDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var config driversDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(
+		d.meta.validateMicroversions(ctx, "ironic_drivers", req.Config, driverMicroversions)...,
+	)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Drivers are not paginated
+	page, err := drivers.ListDrivers(d.meta.Client, drivers.ListDriversOpts{
+		Detail: true,
+		Type:   config.Type.ValueString(),
+	}).AllPages(ctx)
+	var found []drivers.Driver
+	if err == nil {
+		found, err = drivers.ExtractDrivers(page)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to List Drivers",
+			fmt.Sprintf("Unable to list drivers: %s", err),
+		)
+		return
+	}
+
+	config.Drivers = make([]driverModel, 0, len(found))
+	for _, driver := range found {
+		model, err := readDriver(ctx, d.meta.Client, driver)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Driver",
+				fmt.Sprintf("Could not read the properties of driver %s: %s", driver.Name, err),
+			)
+			return
+		}
+		config.Drivers = append(config.Drivers, model)
+	}
+	config.ID = types.StringValue("drivers?type=" + config.Type.ValueString())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
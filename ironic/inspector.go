@@ -0,0 +1,151 @@
+package ironic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+	inspectorbasic "github.com/gophercloud/gophercloud/v2/openstack/baremetalintrospection/httpbasic"
+	inspectornoauth "github.com/gophercloud/gophercloud/v2/openstack/baremetalintrospection/noauth"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetalintrospection/v1/introspection"
+)
+
+// newInspectorClient returns a ServiceClient for Ironic Inspector using the
+// same authentication as Ironic, or nil when no inspector is configured.
+// With Keystone, the endpoint is looked up in the service catalog when it is
+// not set explicitly, and a missing catalog entry is not an error.
+func newInspectorClient(
+	data IronicProviderModel,
+	authStrategy string,
+	ironic *gophercloud.ServiceClient,
+	endpointOpts gophercloud.EndpointOpts,
+	httpClient http.Client,
+) (*gophercloud.ServiceClient, error) {
+	endpoint := stringValueOrEnv(data.Inspector, "IRONIC_INSPECTOR_ENDPOINT")
+
+	var inspector *gophercloud.ServiceClient
+	var err error
+
+	switch authStrategy {
+	case "keystone":
+		if endpoint != "" {
+			return &gophercloud.ServiceClient{
+				ProviderClient: ironic.ProviderClient,
+				Endpoint:       gophercloud.NormalizeURL(endpoint),
+				Type:           "baremetal-introspection",
+			}, nil
+		}
+
+		inspector, err = openstack.NewBareMetalIntrospectionV1(ironic.ProviderClient, endpointOpts)
+		if err != nil {
+			var notFound *gophercloud.ErrEndpointNotFound
+			if errors.As(err, &notFound) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		if !strings.HasSuffix(strings.TrimSuffix(inspector.Endpoint, "/"), "v1") {
+			inspector.ResourceBase = inspector.Endpoint + "v1/"
+		}
+		return inspector, nil
+
+	case "http_basic":
+		if endpoint == "" {
+			return nil, nil
+		}
+		inspector, err = inspectorbasic.NewBareMetalIntrospectionHTTPBasic(inspectorbasic.EndpointOpts{
+			IronicInspectorEndpoint:     endpoint,
+			IronicInspectorUser:         stringValueOrEnv(data.InspectorUsername, "INSPECTOR_HTTP_BASIC_USERNAME"),
+			IronicInspectorUserPassword: stringValueOrEnv(data.InspectorPassword, "INSPECTOR_HTTP_BASIC_PASSWORD"),
+		})
+
+	default:
+		if endpoint == "" {
+			return nil, nil
+		}
+		inspector, err = inspectornoauth.NewBareMetalIntrospectionNoAuth(inspectornoauth.EndpointOpts{
+			IronicInspectorEndpoint: endpoint,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	inspector.HTTPClient = httpClient
+	return inspector, nil
+}
+
+// usesInspector reports whether introspection of the node is handled by a
+// separate Ironic Inspector the provider knows how to reach.
+func (m *Meta) usesInspector(node *nodes.Node) bool {
+	return m.Inspector != nil && node != nil && node.InspectInterface == "inspector"
+}
+
+// requireInspector returns an error when no inspector client is configured.
+func (m *Meta) requireInspector() error {
+	if m.Inspector == nil {
+		return fmt.Errorf("Ironic Inspector is not configured, set the provider `inspector` attribute")
+	}
+	return nil
+}
+
+// introspectionError returns the error reported by Ironic Inspector for the
+// last introspection of the node, if any.
+func (m *Meta) introspectionError(ctx context.Context, nodeUUID string) string {
+	status, err := introspection.GetIntrospectionStatus(ctx, m.Inspector, nodeUUID).Extract()
+	if err != nil {
+		return ""
+	}
+	return status.Error
+}
+
+// introspectionRule is an Ironic Inspector introspection rule, which
+// gophercloud does not implement.
+type introspectionRule struct {
+	UUID        string `json:"uuid,omitempty"`
+	Description string `json:"description,omitempty"`
+	Conditions  []any  `json:"conditions"`
+	Actions     []any  `json:"actions"`
+}
+
+func createIntrospectionRule(
+	ctx context.Context,
+	client *gophercloud.ServiceClient,
+	rule introspectionRule,
+) (*introspectionRule, error) {
+	var result introspectionRule
+	_, err := client.Post(ctx, client.ServiceURL("rules"), rule, &result, &gophercloud.RequestOpts{
+		OkCodes: []int{http.StatusOK, http.StatusCreated},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func getIntrospectionRule(
+	ctx context.Context,
+	client *gophercloud.ServiceClient,
+	uuid string,
+) (*introspectionRule, error) {
+	var result introspectionRule
+	_, err := client.Get(ctx, client.ServiceURL("rules", uuid), &result, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func deleteIntrospectionRule(
+	ctx context.Context,
+	client *gophercloud.ServiceClient,
+	uuid string,
+) error {
+	_, err := client.Delete(ctx, client.ServiceURL("rules", uuid), nil)
+	return err
+}
@@ -0,0 +1,166 @@
+package ironic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	th "github.com/metal3-community/terraform-provider-ironic/testhelper"
+)
+
+const testRuleUUID = "d2c0c7e3-1b3a-4a5e-9d6e-2f0c8a5d0e11"
+
+// newFakeInspector serves just enough of the Ironic Inspector API for
+// introspection status and rule management.
+func newFakeInspector(t *testing.T) (*httptest.Server, *http.Header) {
+	t.Helper()
+
+	var lastHeader http.Header
+	rules := map[string]map[string]any{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/introspection/", func(w http.ResponseWriter, r *http.Request) {
+		lastHeader = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"finished": true, "state": "error", "error": "BMC unreachable", "uuid": "node"}`)
+	})
+	mux.HandleFunc("/v1/rules", func(w http.ResponseWriter, r *http.Request) {
+		lastHeader = r.Header.Clone()
+		var rule map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&rule); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		rule["uuid"] = testRuleUUID
+		rules[testRuleUUID] = rule
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(rule)
+	})
+	mux.HandleFunc("/v1/rules/", func(w http.ResponseWriter, r *http.Request) {
+		uuid := r.URL.Path[len("/v1/rules/"):]
+		rule, ok := rules[uuid]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodDelete:
+			delete(rules, uuid)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(rule)
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server, &lastHeader
+}
+
+func TestNewInspectorClient(t *testing.T) {
+	inspector, lastHeader := newFakeInspector(t)
+	ctx := context.Background()
+
+	client, err := newInspectorClient(IronicProviderModel{}, "noauth", nil, gophercloud.EndpointOpts{}, http.Client{})
+	th.AssertNoError(t, err)
+	if client != nil {
+		t.Errorf("expected no inspector client without an endpoint, got %+v", client)
+	}
+
+	data := IronicProviderModel{
+		Inspector:         types.StringValue(inspector.URL + "/v1"),
+		InspectorUsername: types.StringValue("inspector"),
+		InspectorPassword: types.StringValue("secret"),
+	}
+	client, err = newInspectorClient(data, "http_basic", nil, gophercloud.EndpointOpts{}, http.Client{})
+	th.AssertNoError(t, err)
+
+	meta := &Meta{Inspector: client}
+	if msg := meta.introspectionError(ctx, "node"); msg != "BMC unreachable" {
+		t.Errorf("expected the inspector error, got %q", msg)
+	}
+	if auth := lastHeader.Get("Authorization"); auth != "Basic aW5zcGVjdG9yOnNlY3JldA==" {
+		t.Errorf("unexpected Authorization header %q", auth)
+	}
+
+	if !meta.usesInspector(&nodes.Node{InspectInterface: "inspector"}) {
+		t.Error("expected a node with the inspector interface to use Ironic Inspector")
+	}
+	if meta.usesInspector(&nodes.Node{InspectInterface: "agent"}) {
+		t.Error("expected a node with the agent interface not to use Ironic Inspector")
+	}
+}
+
+func TestNewInspectorClientKeystone(t *testing.T) {
+	keystone, _ := newFakeKeystone(t)
+
+	authOpts := gophercloud.AuthOptions{
+		IdentityEndpoint: keystone.URL + "/identity/v3",
+		Username:         "admin",
+		Password:         "secret",
+		DomainName:       "Default",
+	}
+	endpointOpts := gophercloud.EndpointOpts{Availability: gophercloud.AvailabilityPublic}
+	ironic, err := newKeystoneClient(context.Background(), http.Client{}, authOpts, endpointOpts, "", 0)
+	th.AssertNoError(t, err)
+
+	// The fake catalog has no baremetal-introspection service.
+	client, err := newInspectorClient(IronicProviderModel{}, "keystone", ironic, endpointOpts, http.Client{})
+	th.AssertNoError(t, err)
+	if client != nil {
+		t.Errorf("expected no inspector client, got endpoint %s", client.Endpoint)
+	}
+
+	data := IronicProviderModel{Inspector: types.StringValue("http://inspector.example.com:5050/v1")}
+	client, err = newInspectorClient(data, "keystone", ironic, endpointOpts, http.Client{})
+	th.AssertNoError(t, err)
+	if client.ProviderClient != ironic.ProviderClient {
+		t.Error("expected the inspector client to share the keystone session")
+	}
+}
+
+func TestIntrospectionRules(t *testing.T) {
+	inspector, _ := newFakeInspector(t)
+	ctx := context.Background()
+
+	meta := &Meta{}
+	th.AssertError(t, meta.requireInspector(), "not configured")
+
+	client, err := newInspectorClient(IronicProviderModel{
+		Inspector: types.StringValue(inspector.URL + "/v1"),
+	}, "noauth", nil, gophercloud.EndpointOpts{}, http.Client{})
+	th.AssertNoError(t, err)
+
+	created, err := createIntrospectionRule(ctx, client, introspectionRule{
+		Description: "set deploy kernel",
+		Conditions:  []any{},
+		Actions: []any{
+			map[string]any{"action": "set-attribute", "path": "/driver_info/deploy_kernel", "value": "http://example.com/ipa.kernel"},
+		},
+	})
+	th.AssertNoError(t, err)
+	if created.UUID != testRuleUUID {
+		t.Errorf("unexpected rule UUID %s", created.UUID)
+	}
+
+	rule, err := getIntrospectionRule(ctx, client, testRuleUUID)
+	th.AssertNoError(t, err)
+	if rule.Description != "set deploy kernel" || len(rule.Actions) != 1 {
+		t.Errorf("unexpected rule %+v", rule)
+	}
+
+	th.AssertNoError(t, deleteIntrospectionRule(ctx, client, testRuleUUID))
+	_, err = getIntrospectionRule(ctx, client, testRuleUUID)
+	if !gophercloud.ResponseCodeIs(err, http.StatusNotFound) {
+		t.Errorf("expected a 404 after delete, got %v", err)
+	}
+}
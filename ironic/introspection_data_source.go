@@ -0,0 +1,230 @@
+package ironic
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetalintrospection/v1/introspection"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &IntrospectionDataSource{}
+	_ datasource.DataSourceWithConfigure = &IntrospectionDataSource{}
+)
+
+// IntrospectionDataSource reads the introspection status and data of a node
+// from Ironic Inspector.
+type IntrospectionDataSource struct {
+	meta *Meta
+}
+
+// introspectionDataSourceModel describes the data source data model.
+type introspectionDataSourceModel struct {
+	ID         types.String                  `tfsdk:"id"`
+	UUID       types.String                  `tfsdk:"uuid"`
+	Finished   types.Bool                    `tfsdk:"finished"`
+	State      types.String                  `tfsdk:"state"`
+	Error      types.String                  `tfsdk:"error"`
+	StartedAt  timetypes.RFC3339             `tfsdk:"started_at"`
+	FinishedAt timetypes.RFC3339             `tfsdk:"finished_at"`
+	CPUArch    types.String                  `tfsdk:"cpu_arch"`
+	CPUCount   types.Int64                   `tfsdk:"cpu_count"`
+	MemoryMB   types.Int64                   `tfsdk:"memory_mb"`
+	Interfaces []introspectionInterfaceModel `tfsdk:"interfaces"`
+}
+
+type introspectionInterfaceModel struct {
+	Name types.String `tfsdk:"name"`
+	MAC  types.String `tfsdk:"mac"`
+	IP   types.String `tfsdk:"ip"`
+}
+
+func NewIntrospectionDataSource() datasource.DataSource {
+	return &IntrospectionDataSource{}
+}
+
+func (d *IntrospectionDataSource) Metadata(
+	ctx context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_introspection"
+}
+
+func (d *IntrospectionDataSource) Schema(
+	ctx context.Context,
+	req datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves the introspection status and data of a node from Ironic Inspector. Requires the provider `inspector` endpoint.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Data source identifier.",
+				Computed:            true,
+			},
+			"uuid": schema.StringAttribute{
+				MarkdownDescription: "UUID of the node.",
+				Required:            true,
+			},
+			"finished": schema.BoolAttribute{
+				MarkdownDescription: "Whether introspection has finished.",
+				Computed:            true,
+			},
+			"state": schema.StringAttribute{
+				MarkdownDescription: "State of the introspection.",
+				Computed:            true,
+			},
+			"error": schema.StringAttribute{
+				MarkdownDescription: "Error of the last introspection, if any.",
+				Computed:            true,
+			},
+			"started_at": schema.StringAttribute{
+				MarkdownDescription: "The timestamp when introspection started.",
+				CustomType:          timetypes.RFC3339Type{},
+				Computed:            true,
+			},
+			"finished_at": schema.StringAttribute{
+				MarkdownDescription: "The timestamp when introspection finished.",
+				CustomType:          timetypes.RFC3339Type{},
+				Computed:            true,
+			},
+			"cpu_arch": schema.StringAttribute{
+				MarkdownDescription: "CPU architecture (e.g., x86_64).",
+				Computed:            true,
+			},
+			"cpu_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of CPUs.",
+				Computed:            true,
+			},
+			"memory_mb": schema.Int64Attribute{
+				MarkdownDescription: "Memory in MB.",
+				Computed:            true,
+			},
+			"interfaces": schema.ListNestedAttribute{
+				MarkdownDescription: "Network interfaces discovered during introspection, only populated once finished.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Interface name.",
+							Computed:            true,
+						},
+						"mac": schema.StringAttribute{
+							MarkdownDescription: "MAC address.",
+							Computed:            true,
+						},
+						"ip": schema.StringAttribute{
+							MarkdownDescription: "IP address.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *IntrospectionDataSource) Configure(
+	ctx context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*Meta)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf(
+				"Expected *Clients, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+		return
+	}
+
+	d.meta = clients
+}
+
+func (d *IntrospectionDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var config introspectionDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := d.meta.requireInspector(); err != nil {
+		resp.Diagnostics.AddError("Ironic Inspector Not Configured", err.Error())
+		return
+	}
+
+	nodeUUID := config.UUID.ValueString()
+	tflog.Debug(ctx, "Getting introspection status for node", map[string]any{"uuid": nodeUUID})
+
+	status, err := introspection.GetIntrospectionStatus(ctx, d.meta.Inspector, nodeUUID).Extract()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Get Introspection Status",
+			fmt.Sprintf("Unable to get introspection status for node %s: %s", nodeUUID, err),
+		)
+		return
+	}
+
+	config.ID = types.StringValue(nodeUUID)
+	config.Finished = types.BoolValue(status.Finished)
+	config.State = types.StringValue(status.State)
+	config.Error = types.StringValue(status.Error)
+	config.StartedAt = timeTypeOrNull(status.StartedAt)
+	config.FinishedAt = timeTypeOrNull(status.FinishedAt)
+	config.CPUArch = types.StringNull()
+	config.CPUCount = types.Int64Null()
+	config.MemoryMB = types.Int64Null()
+	config.Interfaces = []introspectionInterfaceModel{}
+
+	// Data is only available once introspection succeeded
+	if status.Finished && status.Error == "" {
+		data, err := introspection.GetIntrospectionData(ctx, d.meta.Inspector, nodeUUID).Extract()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Get Introspection Data",
+				fmt.Sprintf("Unable to get introspection data for node %s: %s", nodeUUID, err),
+			)
+			return
+		}
+
+		config.CPUArch = types.StringValue(data.CPUArch)
+		config.CPUCount = types.Int64Value(int64(data.CPUs))
+		config.MemoryMB = types.Int64Value(int64(data.MemoryMB))
+
+		names := make([]string, 0, len(data.AllInterfaces))
+		for name := range data.AllInterfaces {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			iface := data.AllInterfaces[name]
+			config.Interfaces = append(config.Interfaces, introspectionInterfaceModel{
+				Name: types.StringValue(name),
+				MAC:  types.StringValue(iface.MAC),
+				IP:   types.StringValue(iface.IP),
+			})
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
@@ -0,0 +1,307 @@
+package ironic
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/dynamicplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/metal3-community/terraform-provider-ironic/ironic/util"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &IntrospectionRuleResource{}
+	_ resource.ResourceWithConfigure   = &IntrospectionRuleResource{}
+	_ resource.ResourceWithImportState = &IntrospectionRuleResource{}
+)
+
+// IntrospectionRuleResource manages an Ironic Inspector introspection rule.
+type IntrospectionRuleResource struct {
+	meta *Meta
+}
+
+// IntrospectionRuleResourceModel describes the resource data model.
+type IntrospectionRuleResourceModel struct {
+	ID          types.String  `tfsdk:"id"`
+	Description types.String  `tfsdk:"description"`
+	Conditions  types.Dynamic `tfsdk:"conditions"`
+	Actions     types.Dynamic `tfsdk:"actions"`
+}
+
+func NewIntrospectionRuleResource() resource.Resource {
+	return &IntrospectionRuleResource{}
+}
+
+func (r *IntrospectionRuleResource) Metadata(
+	ctx context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_introspection_rule"
+}
+
+func (r *IntrospectionRuleResource) Schema(
+	ctx context.Context,
+	req resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an Ironic Inspector introspection rule. Rules cannot be modified, so any change replaces the rule. Requires the provider `inspector` endpoint.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The UUID of the rule.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the rule.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"conditions": schema.DynamicAttribute{
+				MarkdownDescription: "List of conditions, each an object with `op`, `field` and optionally `value`, `multiple` and `invert`. The rule applies to every node when empty.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Dynamic{
+					dynamicplanmodifier.RequiresReplace(),
+				},
+			},
+			"actions": schema.DynamicAttribute{
+				MarkdownDescription: "List of actions, each an object with `action` and its arguments.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Dynamic{
+					dynamicplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *IntrospectionRuleResource) Configure(
+	ctx context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*Meta)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf(
+				"Expected *Clients, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+		return
+	}
+
+	r.meta = clients
+}
+
+func (r *IntrospectionRuleResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan IntrospectionRuleResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.meta.requireInspector(); err != nil {
+		resp.Diagnostics.AddError("Ironic Inspector Not Configured", err.Error())
+		return
+	}
+
+	rule := introspectionRule{
+		Description: plan.Description.ValueString(),
+		Conditions:  []any{},
+	}
+
+	conditions, err := util.DynamicToSlice(ctx, plan.Conditions)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("conditions"),
+			"Error Converting Conditions",
+			fmt.Sprintf("Could not convert conditions to a list: %s", err),
+		)
+		return
+	}
+	if conditions != nil {
+		rule.Conditions = conditions
+	}
+
+	rule.Actions, err = util.DynamicToSlice(ctx, plan.Actions)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("actions"),
+			"Error Converting Actions",
+			fmt.Sprintf("Could not convert actions to a list: %s", err),
+		)
+		return
+	}
+
+	created, err := createIntrospectionRule(ctx, r.meta.Inspector, rule)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating introspection rule",
+			fmt.Sprintf("Could not create introspection rule: %s", err),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(created.UUID)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *IntrospectionRuleResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state IntrospectionRuleResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.meta.requireInspector(); err != nil {
+		resp.Diagnostics.AddError("Ironic Inspector Not Configured", err.Error())
+		return
+	}
+
+	found := r.readIntrospectionRuleData(ctx, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *IntrospectionRuleResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	// Ironic Inspector has no way of modifying a rule, all attributes
+	// require replacement so this should not be called
+	resp.Diagnostics.AddError(
+		"Update not supported",
+		"Introspection rules do not support updates. All changes require replacement.",
+	)
+}
+
+func (r *IntrospectionRuleResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var state IntrospectionRuleResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.meta.requireInspector(); err != nil {
+		resp.Diagnostics.AddError("Ironic Inspector Not Configured", err.Error())
+		return
+	}
+
+	err := deleteIntrospectionRule(ctx, r.meta.Inspector, state.ID.ValueString())
+	if err != nil && !gophercloud.ResponseCodeIs(err, http.StatusNotFound) {
+		resp.Diagnostics.AddError(
+			"Error deleting introspection rule",
+			fmt.Sprintf("Could not delete introspection rule %s: %s", state.ID.ValueString(), err),
+		)
+	}
+}
+
+func (r *IntrospectionRuleResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// readIntrospectionRuleData refreshes the model from Ironic Inspector. The
+// conditions and actions are only taken from the API when they are not known
+// yet (on import), as Inspector adds defaults to them. It returns false when
+// the rule no longer exists.
+func (r *IntrospectionRuleResource) readIntrospectionRuleData(
+	ctx context.Context,
+	model *IntrospectionRuleResourceModel,
+	diagnostics *diag.Diagnostics,
+) bool {
+	rule, err := getIntrospectionRule(ctx, r.meta.Inspector, model.ID.ValueString())
+	if err != nil {
+		if gophercloud.ResponseCodeIs(err, http.StatusNotFound) {
+			return false
+		}
+		diagnostics.AddError(
+			"Error reading introspection rule",
+			fmt.Sprintf("Could not read introspection rule %s: %s", model.ID.ValueString(), err),
+		)
+		return false
+	}
+
+	model.ID = types.StringValue(rule.UUID)
+	if rule.Description != "" || !model.Description.IsNull() {
+		model.Description = types.StringValue(rule.Description)
+	}
+
+	if model.Actions.IsNull() {
+		actions, err := util.SliceToDynamic(ctx, rule.Actions)
+		if err != nil {
+			diagnostics.AddError(
+				"Error converting actions",
+				fmt.Sprintf("Could not convert actions to dynamic: %s", err),
+			)
+			return false
+		}
+		model.Actions = actions
+
+		if len(rule.Conditions) > 0 {
+			conditions, err := util.SliceToDynamic(ctx, rule.Conditions)
+			if err != nil {
+				diagnostics.AddError(
+					"Error converting conditions",
+					fmt.Sprintf("Could not convert conditions to dynamic: %s", err),
+				)
+				return false
+			}
+			model.Conditions = conditions
+		}
+	}
+
+	return true
+}
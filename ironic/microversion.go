@@ -0,0 +1,135 @@
+package ironic
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/apiversions"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+// MicroversionAuto selects the highest microversion supported by the server.
+const MicroversionAuto = "auto"
+
+// negotiateMicroversion resolves the requested microversion against the
+// versions advertised by the server. It returns the microversion to use and
+// the highest one the server supports.
+func negotiateMicroversion(
+	ctx context.Context,
+	client *gophercloud.ServiceClient,
+	requested string,
+) (string, string, error) {
+	apiVersions, err := apiversions.List(ctx, client).Extract()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to list Ironic API versions: %w", err)
+	}
+
+	var v1 *apiversions.APIVersion
+	for i := range apiVersions.Versions {
+		if apiVersions.Versions[i].ID == "v1" {
+			v1 = &apiVersions.Versions[i]
+			break
+		}
+	}
+	if v1 == nil || v1.Version == "" {
+		// Very old servers don't advertise microversions, trust the user.
+		if requested == MicroversionAuto {
+			return "", "", fmt.Errorf("the Ironic API does not advertise its supported microversions, set microversion explicitly")
+		}
+		return requested, "", nil
+	}
+
+	if requested == MicroversionAuto {
+		return v1.Version, v1.Version, nil
+	}
+
+	if !microversionAtLeast(v1.Version, requested) {
+		return "", "", fmt.Errorf("microversion %s is not supported by the Ironic API, the maximum is %s",
+			requested, v1.Version)
+	}
+	if v1.MinVersion != "" && !microversionAtLeast(requested, v1.MinVersion) {
+		return "", "", fmt.Errorf("microversion %s is not supported by the Ironic API, the minimum is %s",
+			requested, v1.MinVersion)
+	}
+
+	return requested, v1.Version, nil
+}
+
+// microversionAtLeast reports whether actual is the same as or newer than
+// required. Unparseable versions never satisfy the requirement.
+func microversionAtLeast(actual, required string) bool {
+	a, err := version.NewVersion(actual)
+	if err != nil {
+		return false
+	}
+	r, err := version.NewVersion(required)
+	if err != nil {
+		return false
+	}
+	return !a.LessThan(r)
+}
+
+// microversionRequirement is the minimum microversion needed to use an
+// attribute. An empty attribute applies to the resource as a whole.
+type microversionRequirement struct {
+	Attribute string
+	Version   string
+}
+
+// checkMicroversion returns a diagnostic explaining how to proceed when the
+// client microversion is older than required for feature.
+func (m *Meta) checkMicroversion(feature, required string) diag.Diagnostic {
+	if m == nil || m.Client == nil || m.Client.Microversion == "" ||
+		microversionAtLeast(m.Client.Microversion, required) {
+		return nil
+	}
+
+	detail := fmt.Sprintf("%s requires Ironic API microversion %s, but the provider is using %s.",
+		feature, required, m.Client.Microversion)
+	if m.MaxMicroversion != "" && !microversionAtLeast(m.MaxMicroversion, required) {
+		detail += fmt.Sprintf(" The Ironic API only supports up to %s, upgrade Ironic to use it.",
+			m.MaxMicroversion)
+	} else {
+		detail += " Raise the provider microversion or set it to \"auto\"."
+	}
+
+	return diag.NewErrorDiagnostic("Unsupported Ironic API microversion", detail)
+}
+
+// validateMicroversions checks the configured attributes of a resource
+// against their microversion requirements.
+func (m *Meta) validateMicroversions(
+	ctx context.Context,
+	typeName string,
+	config tfsdk.Config,
+	requirements []microversionRequirement,
+) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, requirement := range requirements {
+		if requirement.Attribute == "" {
+			if d := m.checkMicroversion(typeName, requirement.Version); d != nil {
+				diags.Append(d)
+			}
+			continue
+		}
+
+		var value attr.Value
+		diags.Append(config.GetAttribute(ctx, path.Root(requirement.Attribute), &value)...)
+		if value == nil || value.IsNull() {
+			continue
+		}
+
+		feature := fmt.Sprintf("The %s attribute of %s", requirement.Attribute, typeName)
+		if d := m.checkMicroversion(feature, requirement.Version); d != nil {
+			diags.AddAttributeError(path.Root(requirement.Attribute), d.Summary(), d.Detail())
+		}
+	}
+
+	return diags
+}
@@ -0,0 +1,133 @@
+package ironic
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/noauth"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	th "github.com/metal3-community/terraform-provider-ironic/testhelper"
+)
+
+func newVersionedIronic(t *testing.T, versions string) *gophercloud.ServiceClient {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, versions)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := noauth.NewBareMetalNoAuth(noauth.EndpointOpts{
+		IronicEndpoint: server.URL + "/v1",
+	})
+	th.AssertNoError(t, err)
+
+	return client
+}
+
+func TestNegotiateMicroversion(t *testing.T) {
+	client := newVersionedIronic(t,
+		`{"versions": [{"id": "v1", "status": "CURRENT", "min_version": "1.1", "version": "1.89"}]}`)
+
+	tests := []struct {
+		requested string
+		expected  string
+		err       string
+	}{
+		{requested: "auto", expected: "1.89"},
+		{requested: "1.81", expected: "1.81"},
+		{requested: "1.89", expected: "1.89"},
+		{requested: "1.99", err: "the maximum is 1.89"},
+		{requested: "1.0", err: "the minimum is 1.1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.requested, func(t *testing.T) {
+			negotiated, maxVersion, err := negotiateMicroversion(context.Background(), client, test.requested)
+			if test.err != "" {
+				th.AssertError(t, err, test.err)
+				return
+			}
+			th.AssertNoError(t, err)
+			if negotiated != test.expected {
+				t.Errorf("negotiated %s, expected %s", negotiated, test.expected)
+			}
+			if maxVersion != "1.89" {
+				t.Errorf("max microversion %s, expected 1.89", maxVersion)
+			}
+		})
+	}
+}
+
+func TestNegotiateMicroversionNotAdvertised(t *testing.T) {
+	client := newVersionedIronic(t, `{"versions": [{"id": "v1", "status": "CURRENT"}]}`)
+
+	negotiated, _, err := negotiateMicroversion(context.Background(), client, "1.31")
+	th.AssertNoError(t, err)
+	if negotiated != "1.31" {
+		t.Errorf("negotiated %s, expected 1.31", negotiated)
+	}
+
+	_, _, err = negotiateMicroversion(context.Background(), client, "auto")
+	th.AssertError(t, err, "set microversion explicitly")
+}
+
+func TestCheckMicroversion(t *testing.T) {
+	meta := &Meta{
+		Client:          &gophercloud.ServiceClient{Microversion: "1.50"},
+		MaxMicroversion: "1.65",
+	}
+
+	if d := meta.checkMicroversion("owner", "1.50"); d != nil {
+		t.Errorf("unexpected diagnostic: %s", d.Detail())
+	}
+
+	d := meta.checkMicroversion("lessee", "1.65")
+	if d == nil || !strings.Contains(d.Detail(), `set it to "auto"`) {
+		t.Errorf("expected a hint to raise the microversion, got %v", d)
+	}
+
+	d = meta.checkMicroversion("firmware_interface", "1.86")
+	if d == nil || !strings.Contains(d.Detail(), "only supports up to 1.65") {
+		t.Errorf("expected a hint to upgrade Ironic, got %v", d)
+	}
+}
+
+func TestValidateMicroversions(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	NewPortV1Resource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	portType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	values := map[string]tftypes.Value{}
+	for name, attrType := range portType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	values["address"] = tftypes.NewValue(tftypes.String, "52:54:00:12:34:56")
+	values["physical_network"] = tftypes.NewValue(tftypes.String, "provisioning")
+
+	config := tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(portType, values),
+	}
+
+	meta := &Meta{Client: &gophercloud.ServiceClient{Microversion: "1.31"}}
+	diags := meta.validateMicroversions(ctx, "ironic_port", config, portMicroversions)
+	if diags.ErrorsCount() != 1 || !strings.Contains(diags.Errors()[0].Detail(), "physical_network") {
+		t.Errorf("expected a single physical_network error, got %v", diags)
+	}
+
+	meta.Client.Microversion = "1.53"
+	if diags := meta.validateMicroversions(ctx, "ironic_port", config, portMicroversions); diags.HasError() {
+		t.Errorf("unexpected errors: %v", diags)
+	}
+}
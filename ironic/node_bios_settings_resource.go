@@ -0,0 +1,383 @@
+package ironic
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &NodeBIOSSettingsResource{}
+	_ resource.ResourceWithConfigure   = &NodeBIOSSettingsResource{}
+	_ resource.ResourceWithImportState = &NodeBIOSSettingsResource{}
+	_ resource.ResourceWithModifyPlan  = &NodeBIOSSettingsResource{}
+)
+
+// nodeBIOSSettingsMicroversions lists the microversions needed by BIOS
+// settings, the API itself first appeared in 1.40.
+var nodeBIOSSettingsMicroversions = []microversionRequirement{
+	{Version: "1.40"},
+}
+
+// NodeBIOSSettingsResource manages BIOS settings of an Ironic node.
+type NodeBIOSSettingsResource struct {
+	meta *Meta
+}
+
+// NodeBIOSSettingsResourceModel describes the resource data model.
+type NodeBIOSSettingsResourceModel struct {
+	ID       types.String   `tfsdk:"id"`
+	NodeUUID types.String   `tfsdk:"node_uuid"`
+	Settings types.Map      `tfsdk:"settings"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewNodeBIOSSettingsResource() resource.Resource {
+	return &NodeBIOSSettingsResource{}
+}
+
+func (r *NodeBIOSSettingsResource) Metadata(
+	ctx context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_node_bios_settings"
+}
+
+func (r *NodeBIOSSettingsResource) Schema(
+	ctx context.Context,
+	req resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages BIOS settings of an Ironic node. Settings that differ from the node BIOS are applied with the `bios.apply_configuration` clean step, which requires the node to be in the `enroll`, `manageable` or `available` state; available nodes are made available again afterwards. Only the configured settings are managed, and destroying the resource leaves the BIOS as it is.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The UUID of the node.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"node_uuid": schema.StringAttribute{
+				MarkdownDescription: "The UUID of the node.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"settings": schema.MapAttribute{
+				MarkdownDescription: "BIOS settings by name, as reported by the node BIOS API.",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
+	}
+}
+
+func (r *NodeBIOSSettingsResource) Configure(
+	ctx context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*Meta)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf(
+				"Expected *Clients, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+		return
+	}
+
+	r.meta = clients
+}
+
+// ModifyPlan reports when the Ironic API microversion is too old for BIOS
+// settings.
+func (r *NodeBIOSSettingsResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	// Nothing to check on destroy, or before the provider is configured.
+	if req.Plan.Raw.IsNull() || r.meta == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(
+		r.meta.validateMicroversions(ctx, "ironic_node_bios_settings", req.Config, nodeBIOSSettingsMicroversions)...,
+	)
+}
+
+func (r *NodeBIOSSettingsResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan NodeBIOSSettingsResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := operationContext(ctx, createTimeout)
+	defer cancel()
+
+	plan.ID = plan.NodeUUID
+
+	r.applyBIOSSettings(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *NodeBIOSSettingsResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state NodeBIOSSettingsResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, err := r.readBIOSSettings(ctx, state.ID.ValueString())
+	if err != nil {
+		if gophercloud.ResponseCodeIs(err, http.StatusNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error reading BIOS settings",
+			fmt.Sprintf("Could not read BIOS settings of node %s: %s", state.ID.ValueString(), err),
+		)
+		return
+	}
+
+	// Only report the managed settings, all of them when importing
+	settings := current
+	if !state.Settings.IsNull() {
+		var managed map[string]string
+		resp.Diagnostics.Append(state.Settings.ElementsAs(ctx, &managed, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		settings = map[string]string{}
+		for name := range managed {
+			if value, ok := current[name]; ok {
+				settings[name] = value
+			}
+		}
+	}
+
+	state.NodeUUID = state.ID
+	state.Settings, diags = types.MapValueFrom(ctx, types.StringType, settings)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *NodeBIOSSettingsResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var plan NodeBIOSSettingsResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := operationContext(ctx, updateTimeout)
+	defer cancel()
+
+	r.applyBIOSSettings(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *NodeBIOSSettingsResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	// BIOS settings cannot be removed, they stay as they are
+	tflog.Info(ctx, "Leaving BIOS settings of node unchanged")
+}
+
+func (r *NodeBIOSSettingsResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// readBIOSSettings returns the current BIOS settings of the node by name.
+func (r *NodeBIOSSettingsResource) readBIOSSettings(
+	ctx context.Context,
+	nodeUUID string,
+) (map[string]string, error) {
+	settings, err := nodes.ListBIOSSettings(ctx, r.meta.Client, nodeUUID, nil).Extract()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]string, len(settings))
+	for _, setting := range settings {
+		result[setting.Name] = setting.Value
+	}
+	return result, nil
+}
+
+// applyBIOSSettings runs the bios.apply_configuration clean step with the
+// settings that differ from the node BIOS, and checks they were applied.
+func (r *NodeBIOSSettingsResource) applyBIOSSettings(
+	ctx context.Context,
+	model *NodeBIOSSettingsResourceModel,
+	diagnostics *diag.Diagnostics,
+) {
+	nodeUUID := model.NodeUUID.ValueString()
+
+	var desired map[string]string
+	diagnostics.Append(model.Settings.ElementsAs(ctx, &desired, false)...)
+	if diagnostics.HasError() {
+		return
+	}
+
+	current, err := r.readBIOSSettings(ctx, nodeUUID)
+	if err != nil {
+		diagnostics.AddError(
+			"Error reading BIOS settings",
+			fmt.Sprintf("Could not read BIOS settings of node %s: %s", nodeUUID, err),
+		)
+		return
+	}
+
+	changes := biosSettingsChanges(current, desired)
+	if len(changes) == 0 {
+		return
+	}
+
+	tflog.Info(ctx, "Applying BIOS settings", map[string]any{
+		"node_id":  nodeUUID,
+		"settings": changes,
+	})
+
+	cleanSteps := []nodes.CleanStep{
+		{
+			Interface: nodes.InterfaceBIOS,
+			Step:      "apply_configuration",
+			Args:      map[string]any{"settings": changes},
+		},
+	}
+	if err := runManualClean(ctx, r.meta.Client, nodeUUID, cleanSteps); err != nil {
+		diagnostics.AddError(
+			"Error applying BIOS settings",
+			fmt.Sprintf("Could not apply BIOS settings to node %s: %s", nodeUUID, err),
+		)
+		return
+	}
+
+	current, err = r.readBIOSSettings(ctx, nodeUUID)
+	if err != nil {
+		diagnostics.AddError(
+			"Error reading BIOS settings",
+			fmt.Sprintf("Could not read BIOS settings of node %s: %s", nodeUUID, err),
+		)
+		return
+	}
+	for _, change := range biosSettingsChanges(current, desired) {
+		diagnostics.AddAttributeError(
+			path.Root("settings").AtMapKey(change["name"]),
+			"BIOS Setting Not Applied",
+			fmt.Sprintf("Node %s reports %q for %s after applying %q.",
+				nodeUUID, current[change["name"]], change["name"], change["value"]),
+		)
+	}
+}
+
+// biosSettingsChanges returns the desired settings that differ from the
+// current ones, in the format of the bios.apply_configuration clean step and
+// sorted by name.
+func biosSettingsChanges(current, desired map[string]string) []map[string]string {
+	var changes []map[string]string
+	for name, value := range desired {
+		if currentValue, ok := current[name]; ok && currentValue == value {
+			continue
+		}
+		changes = append(changes, map[string]string{"name": name, "value": value})
+	}
+	slices.SortFunc(changes, func(a, b map[string]string) int {
+		if a["name"] < b["name"] {
+			return -1
+		}
+		if a["name"] > b["name"] {
+			return 1
+		}
+		return 0
+	})
+	return changes
+}
@@ -0,0 +1,181 @@
+package ironic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/noauth"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	th "github.com/metal3-community/terraform-provider-ironic/testhelper"
+)
+
+// fakeBIOSIronic is a fake Ironic that applies the settings passed to the
+// bios.apply_configuration clean step. Settings listed in ignored are
+// accepted but left unchanged, like a BIOS refusing a value.
+type fakeBIOSIronic struct {
+	mu             sync.Mutex
+	provisionState string
+	settings       map[string]string
+	ignored        map[string]bool
+	targets        []string
+}
+
+func (f *fakeBIOSIronic) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.Method + " " + r.URL.Path {
+	case "GET /v1/nodes/node":
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"uuid": "node", "provision_state": %q}`, f.provisionState)
+	case "GET /v1/nodes/node/bios":
+		var settings []map[string]string
+		for name, value := range f.settings {
+			settings = append(settings, map[string]string{"name": name, "value": value})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"bios": settings})
+	case "PUT /v1/nodes/node/states/provision":
+		var body struct {
+			Target     string `json:"target"`
+			CleanSteps []struct {
+				Args struct {
+					Settings []map[string]string `json:"settings"`
+				} `json:"args"`
+			} `json:"clean_steps"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.targets = append(f.targets, body.Target)
+		switch body.Target {
+		case "manage":
+			f.provisionState = "manageable"
+		case "provide":
+			f.provisionState = "available"
+		case "clean":
+			for _, step := range body.CleanSteps {
+				for _, setting := range step.Args.Settings {
+					if !f.ignored[setting["name"]] {
+						f.settings[setting["name"]] = setting["value"]
+					}
+				}
+			}
+			f.provisionState = "manageable"
+		}
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestBIOSSettingsChanges(t *testing.T) {
+	changes := biosSettingsChanges(
+		map[string]string{"BootMode": "Uefi", "ProcTurboMode": "Enabled"},
+		map[string]string{"BootMode": "Uefi", "ProcTurboMode": "Disabled", "LogicalProc": "Enabled"},
+	)
+	expected := []map[string]string{
+		{"name": "LogicalProc", "value": "Enabled"},
+		{"name": "ProcTurboMode", "value": "Disabled"},
+	}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("unexpected changes: %v", changes)
+	}
+}
+
+func TestApplyBIOSSettings(t *testing.T) {
+	defer func(interval time.Duration) { workflowPollInterval = interval }(workflowPollInterval)
+	workflowPollInterval = 10 * time.Millisecond
+
+	tests := []struct {
+		name           string
+		provisionState string
+		settings       map[string]string
+		ignored        map[string]bool
+		targets        []string
+		finalState     string
+		errors         int
+	}{
+		{
+			name:           "unchanged",
+			provisionState: "active",
+			settings:       map[string]string{"BootMode": "Uefi"},
+		},
+		{
+			name:           "available",
+			provisionState: "available",
+			settings:       map[string]string{"BootMode": "Bios"},
+			targets:        []string{"manage", "clean", "provide"},
+			finalState:     "available",
+		},
+		{
+			name:           "manageable",
+			provisionState: "manageable",
+			settings:       map[string]string{"BootMode": "Bios"},
+			targets:        []string{"clean"},
+			finalState:     "manageable",
+		},
+		{
+			name:           "active",
+			provisionState: "active",
+			settings:       map[string]string{"BootMode": "Bios"},
+			finalState:     "active",
+			errors:         1,
+		},
+		{
+			name:           "not applied",
+			provisionState: "manageable",
+			settings:       map[string]string{"BootMode": "Bios"},
+			ignored:        map[string]bool{"BootMode": true},
+			targets:        []string{"clean"},
+			finalState:     "manageable",
+			errors:         1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fake := &fakeBIOSIronic{
+				provisionState: test.provisionState,
+				settings:       map[string]string{"BootMode": "Uefi", "ProcTurboMode": "Enabled"},
+				ignored:        test.ignored,
+			}
+			server := httptest.NewServer(fake)
+			defer server.Close()
+
+			client, err := noauth.NewBareMetalNoAuth(noauth.EndpointOpts{
+				IronicEndpoint: server.URL + "/v1",
+			})
+			th.AssertNoError(t, err)
+
+			settings, diags := types.MapValueFrom(context.Background(), types.StringType, test.settings)
+			if diags.HasError() {
+				t.Fatalf("unexpected errors: %v", diags)
+			}
+
+			r := &NodeBIOSSettingsResource{meta: &Meta{Client: client}}
+			model := &NodeBIOSSettingsResourceModel{
+				NodeUUID: types.StringValue("node"),
+				Settings: settings,
+			}
+
+			diags = diag.Diagnostics{}
+			r.applyBIOSSettings(context.Background(), model, &diags)
+			if diags.ErrorsCount() != test.errors {
+				t.Errorf("expected %d errors, got %v", test.errors, diags)
+			}
+			if !reflect.DeepEqual(fake.targets, test.targets) {
+				t.Errorf("expected targets %v, got %v", test.targets, fake.targets)
+			}
+			if test.finalState != "" && fake.provisionState != test.finalState {
+				t.Errorf("expected node to be %s, got %s", test.finalState, fake.provisionState)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package ironic
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// manualStepObject returns the schema of a clean or service step.
+func manualStepObject(kind string) schema.NestedAttributeObject {
+	return schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"interface": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("The interface of the %s step.", kind),
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(nodes.InterfaceBIOS),
+						string(nodes.InterfaceDeploy),
+						string(nodes.InterfaceFirmware),
+						string(nodes.InterfaceManagement),
+						string(nodes.InterfacePower),
+						string(nodes.InterfaceRAID),
+					),
+				},
+			},
+			"step": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("The name of the %s step.", kind),
+				Required:            true,
+			},
+			"args": schema.MapAttribute{
+				MarkdownDescription: fmt.Sprintf("Arguments for the %s step. Values that are valid JSON are decoded, so nested arguments can be passed with `jsonencode()`.", kind),
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"priority": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The priority of the %s step.", kind),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+// cleanStepModel describes a clean or service step, service steps look the
+// same to Ironic.
+type cleanStepModel struct {
+	Interface types.String `tfsdk:"interface"`
+	Step      types.String `tfsdk:"step"`
+	Args      types.Map    `tfsdk:"args"`
+	Priority  types.Int64  `tfsdk:"priority"`
+}
+
+// buildCleanSteps converts a list of clean or service steps to the Ironic
+// format.
+// Steps with a priority run first, highest priority first, and steps with the
+// same or no priority run in list order.
+func buildCleanSteps(ctx context.Context, list types.List) ([]nodes.CleanStep, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var models []cleanStepModel
+	diags.Append(list.ElementsAs(ctx, &models, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	slices.SortStableFunc(models, func(a, b cleanStepModel) int {
+		return cmp.Compare(b.Priority.ValueInt64(), a.Priority.ValueInt64())
+	})
+
+	cleanSteps := make([]nodes.CleanStep, len(models))
+	for i, model := range models {
+		var args map[string]string
+		diags.Append(model.Args.ElementsAs(ctx, &args, false)...)
+
+		cleanSteps[i] = nodes.CleanStep{
+			Interface: nodes.StepInterface(model.Interface.ValueString()),
+			Step:      model.Step.ValueString(),
+			Args:      decodeStepArgs(args),
+		}
+	}
+	return cleanSteps, diags
+}
+
+// decodeStepArgs converts step arguments given as strings. Values that are
+// valid JSON are decoded, so numbers, booleans and nested arguments can be
+// passed with jsonencode(); anything else is kept as a string.
+func decodeStepArgs(args map[string]string) map[string]any {
+	if len(args) == 0 {
+		return nil
+	}
+
+	decoded := make(map[string]any, len(args))
+	for name, value := range args {
+		var v any
+		if err := json.Unmarshal([]byte(value), &v); err != nil {
+			v = value
+		}
+		decoded[name] = v
+	}
+	return decoded
+}
@@ -0,0 +1,156 @@
+package ironic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/noauth"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	th "github.com/metal3-community/terraform-provider-ironic/testhelper"
+)
+
+func TestBuildCleanSteps(t *testing.T) {
+	stepType := types.ObjectType{AttrTypes: map[string]attr.Type{
+		"interface": types.StringType,
+		"step":      types.StringType,
+		"args":      types.MapType{ElemType: types.StringType},
+		"priority":  types.Int64Type,
+	}}
+	step := func(iface, name string, args map[string]string, priority types.Int64) attr.Value {
+		argsValue := types.MapNull(types.StringType)
+		if args != nil {
+			argsValue, _ = types.MapValueFrom(context.Background(), types.StringType, args)
+		}
+		return types.ObjectValueMust(stepType.AttrTypes, map[string]attr.Value{
+			"interface": types.StringValue(iface),
+			"step":      types.StringValue(name),
+			"args":      argsValue,
+			"priority":  priority,
+		})
+	}
+
+	list := types.ListValueMust(stepType, []attr.Value{
+		step("deploy", "erase_devices_metadata", nil, types.Int64Null()),
+		step("bios", "apply_configuration", map[string]string{
+			"settings": `[{"name": "BootMode", "value": "Uefi"}]`,
+		}, types.Int64Value(10)),
+		step("management", "update_firmware", map[string]string{
+			"url":   "http://172.22.0.1/firmware.bin",
+			"force": "true",
+		}, types.Int64Null()),
+	})
+
+	cleanSteps, diags := buildCleanSteps(context.Background(), list)
+	if diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags)
+	}
+
+	expected := []nodes.CleanStep{
+		{
+			Interface: nodes.InterfaceBIOS,
+			Step:      "apply_configuration",
+			Args: map[string]any{
+				"settings": []any{map[string]any{"name": "BootMode", "value": "Uefi"}},
+			},
+		},
+		{Interface: nodes.InterfaceDeploy, Step: "erase_devices_metadata"},
+		{
+			Interface: nodes.InterfaceManagement,
+			Step:      "update_firmware",
+			Args:      map[string]any{"url": "http://172.22.0.1/firmware.bin", "force": true},
+		},
+	}
+	if !reflect.DeepEqual(cleanSteps, expected) {
+		t.Errorf("unexpected clean steps: %+v", cleanSteps)
+	}
+}
+
+func TestProvisionWorkflowDisableRamdisk(t *testing.T) {
+	defer func(interval time.Duration) { workflowPollInterval = interval }(workflowPollInterval)
+	workflowPollInterval = 10 * time.Millisecond
+
+	state := nodes.Manageable
+	var requested map[string]any
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/nodes/node/states/provision", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&requested)
+		state = nodes.Cleaning
+		w.WriteHeader(http.StatusAccepted)
+	})
+	mux.HandleFunc("/v1/nodes/node", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"uuid": "node", "provision_state": %q}`, state)
+		if state == nodes.Cleaning {
+			state = nodes.Manageable
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := noauth.NewBareMetalNoAuth(noauth.EndpointOpts{
+		IronicEndpoint: server.URL + "/v1",
+	})
+	th.AssertNoError(t, err)
+
+	err = runProvisionWorkflow(&provisionWorkflow{
+		ctx:            context.Background(),
+		client:         client,
+		nodeID:         "node",
+		target:         nodes.TargetClean,
+		cleanSteps:     []nodes.CleanStep{{Interface: nodes.InterfaceManagement, Step: "clear_job_queue"}},
+		disableRamdisk: true,
+	})
+	th.AssertNoError(t, err)
+
+	if requested["target"] != "clean" || requested["disable_ramdisk"] != true {
+		t.Errorf("unexpected provision state request: %v", requested)
+	}
+}
+
+func TestProvisionWorkflowRejectedClean(t *testing.T) {
+	defer func(interval time.Duration) { workflowPollInterval = interval }(workflowPollInterval)
+	workflowPollInterval = 10 * time.Millisecond
+
+	requests := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/nodes/node/states/provision", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error_message": "clean_steps is required when setting target provision state to clean"}`)
+	})
+	mux.HandleFunc("/v1/nodes/node", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"uuid": "node", "provision_state": %q}`, nodes.Manageable)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := noauth.NewBareMetalNoAuth(noauth.EndpointOpts{
+		IronicEndpoint: server.URL + "/v1",
+	})
+	th.AssertNoError(t, err)
+
+	// A rejected request fails the workflow instead of being retried until
+	// the timeout
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	err = runProvisionWorkflow(&provisionWorkflow{
+		ctx:    ctx,
+		client: client,
+		nodeID: "node",
+		target: nodes.TargetClean,
+	})
+	th.AssertError(t, err, "clean_steps is required")
+	if requests != 1 {
+		t.Errorf("expected the rejected request to be sent once, got %d", requests)
+	}
+}
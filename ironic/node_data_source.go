@@ -0,0 +1,511 @@
+package ironic
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/ports"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                     = &NodeDataSource{}
+	_ datasource.DataSourceWithConfigure        = &NodeDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &NodeDataSource{}
+)
+
+// nodeResourceOnlyAttributes are the ironic_node attributes that request an
+// action rather than describe the node, they are left out of the data source.
+var nodeResourceOnlyAttributes = []string{
+	"available",
+	"clean",
+	"clean_steps",
+	"disable_ramdisk",
+	"inspect",
+	"manage",
+	"power_state_timeout",
+}
+
+// nodeLookupAttributes are the attributes a node can be looked up by.
+var nodeLookupAttributes = []string{"uuid", "name", "instance_uuid", "mac_address"}
+
+// NodeDataSource looks up an existing Ironic node.
+type NodeDataSource struct {
+	meta *Meta
+}
+
+// nodeLookup describes how to find the node.
+type nodeLookup struct {
+	UUID         types.String
+	Name         types.String
+	Namespace    types.String
+	InstanceUUID types.String
+	MACAddress   types.String
+}
+
+func NewNodeDataSource() datasource.DataSource {
+	return &NodeDataSource{}
+}
+
+func (d *NodeDataSource) Metadata(
+	ctx context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_node"
+}
+
+// Schema returns the attributes of the ironic_node resource that describe the
+// node as computed attributes, plus the attributes the node is looked up by.
+func (d *NodeDataSource) Schema(
+	ctx context.Context,
+	req datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up an existing Ironic node by UUID, name, instance UUID or port MAC address, and returns the same attributes as the `ironic_node` resource.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Unique identifier of the node.",
+				Computed:            true,
+			},
+			"uuid": schema.StringAttribute{
+				MarkdownDescription: "The UUID of the node to look up.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"mac_address": schema.StringAttribute{
+				MarkdownDescription: "Look the node up by the MAC address of one of its ports.",
+				Optional:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the node, or the name to look the node up by.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"namespace": schema.StringAttribute{
+				MarkdownDescription: "The namespace of the node, used with `name` to look up nodes named `namespace~name`.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"full_name": schema.StringAttribute{
+				MarkdownDescription: "The full name of the node, combining name and namespace.",
+				Computed:            true,
+			},
+			"network_interface": schema.StringAttribute{
+				MarkdownDescription: "The network interface for the node.",
+				Computed:            true,
+			},
+			"driver": schema.StringAttribute{
+				MarkdownDescription: "The driver associated with the node.",
+				Computed:            true,
+			},
+			"boot_interface": schema.StringAttribute{
+				MarkdownDescription: "The boot interface for the node.",
+				Computed:            true,
+			},
+			"console_interface": schema.StringAttribute{
+				MarkdownDescription: "The console interface for the node.",
+				Computed:            true,
+			},
+			"deploy_interface": schema.StringAttribute{
+				MarkdownDescription: "The deploy interface for the node.",
+				Computed:            true,
+			},
+			"inspect_interface": schema.StringAttribute{
+				MarkdownDescription: "The inspect interface for the node.",
+				Computed:            true,
+			},
+			"management_interface": schema.StringAttribute{
+				MarkdownDescription: "The management interface for the node.",
+				Computed:            true,
+			},
+			"power_interface": schema.StringAttribute{
+				MarkdownDescription: "The power interface for the node.",
+				Computed:            true,
+			},
+			"raid_interface": schema.StringAttribute{
+				MarkdownDescription: "The RAID interface for the node.",
+				Computed:            true,
+			},
+			"rescue_interface": schema.StringAttribute{
+				MarkdownDescription: "The rescue interface for the node.",
+				Computed:            true,
+			},
+			"storage_interface": schema.StringAttribute{
+				MarkdownDescription: "The storage interface for the node.",
+				Computed:            true,
+			},
+			"vendor_interface": schema.StringAttribute{
+				MarkdownDescription: "The vendor interface for the node.",
+				Computed:            true,
+			},
+			"automated_clean": schema.BoolAttribute{
+				MarkdownDescription: "Whether the node is cleaned automatically.",
+				Computed:            true,
+			},
+			"protected": schema.BoolAttribute{
+				MarkdownDescription: "Whether the node is protected.",
+				Computed:            true,
+			},
+			"maintenance": schema.BoolAttribute{
+				MarkdownDescription: "Whether the node is in maintenance mode.",
+				Computed:            true,
+			},
+			"maintenance_reason": schema.StringAttribute{
+				MarkdownDescription: "The reason the node is in maintenance mode.",
+				Computed:            true,
+			},
+			"properties": schema.DynamicAttribute{
+				MarkdownDescription: "The properties of the node.",
+				Computed:            true,
+			},
+			"driver_info": schema.DynamicAttribute{
+				MarkdownDescription: "The driver info of the node.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"instance_info": schema.DynamicAttribute{
+				MarkdownDescription: "The instance info of the node.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"instance_uuid": schema.StringAttribute{
+				MarkdownDescription: "The UUID of the instance associated with the node, or the instance UUID to look the node up by.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"resource_class": schema.StringAttribute{
+				MarkdownDescription: "The resource class of the node.",
+				Computed:            true,
+			},
+			"provision_state": schema.StringAttribute{
+				MarkdownDescription: "The current provision state of the node.",
+				Computed:            true,
+			},
+			"power_state": schema.StringAttribute{
+				MarkdownDescription: "The current power state of the node.",
+				Computed:            true,
+			},
+			"target_provision_state": schema.StringAttribute{
+				MarkdownDescription: "The target provision state of the node.",
+				Computed:            true,
+			},
+			"target_power_state": schema.StringAttribute{
+				MarkdownDescription: "The power state Ironic is moving the node to, if any.",
+				Computed:            true,
+			},
+			"last_error": schema.StringAttribute{
+				MarkdownDescription: "The last error message for the node.",
+				Computed:            true,
+			},
+			"extra": schema.DynamicAttribute{
+				MarkdownDescription: "Extra metadata for the node.",
+				Computed:            true,
+			},
+			"owner": schema.StringAttribute{
+				MarkdownDescription: "The owner of the node.",
+				Computed:            true,
+			},
+			"lessee": schema.StringAttribute{
+				MarkdownDescription: "The lessee of the node.",
+				Computed:            true,
+			},
+			"traits": schema.SetAttribute{
+				MarkdownDescription: "The traits of the node.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"conductor": schema.StringAttribute{
+				MarkdownDescription: "The conductor managing the node.",
+				Computed:            true,
+			},
+			"conductor_group": schema.StringAttribute{
+				MarkdownDescription: "The conductor group for the node.",
+				Computed:            true,
+			},
+			"allocation_uuid": schema.StringAttribute{
+				MarkdownDescription: "The UUID of the allocation associated with the node.",
+				Computed:            true,
+			},
+			"chassis_uuid": schema.StringAttribute{
+				MarkdownDescription: "The UUID of the chassis associated with the node.",
+				Computed:            true,
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "The timestamp when the node was created.",
+				CustomType:          timetypes.RFC3339Type{},
+				Computed:            true,
+			},
+			"updated_at": schema.StringAttribute{
+				MarkdownDescription: "The timestamp when the node was last updated.",
+				CustomType:          timetypes.RFC3339Type{},
+				Computed:            true,
+			},
+			"inspection_started_at": schema.StringAttribute{
+				MarkdownDescription: "The timestamp when the node inspection started.",
+				CustomType:          timetypes.RFC3339Type{},
+				Computed:            true,
+			},
+			"inspection_finished_at": schema.StringAttribute{
+				MarkdownDescription: "The timestamp when the node inspection finished.",
+				CustomType:          timetypes.RFC3339Type{},
+				Computed:            true,
+			},
+			"provision_updated_at": schema.StringAttribute{
+				MarkdownDescription: "The timestamp when the node provision was last updated.",
+				CustomType:          timetypes.RFC3339Type{},
+				Computed:            true,
+			},
+			"clean_step": schema.DynamicAttribute{
+				MarkdownDescription: "The current clean step for the node.",
+				Computed:            true,
+			},
+			"deploy_step": schema.DynamicAttribute{
+				MarkdownDescription: "The current deploy step for the node.",
+				Computed:            true,
+			},
+			"fault": schema.StringAttribute{
+				MarkdownDescription: "The fault status of the node.",
+				Computed:            true,
+			},
+			"bios_interface": schema.StringAttribute{
+				MarkdownDescription: "The BIOS interface for the node.",
+				Computed:            true,
+			},
+			"firmware_interface": schema.StringAttribute{
+				MarkdownDescription: "The firmware interface for the node.",
+				Computed:            true,
+			},
+			"ports": schema.ListNestedAttribute{
+				MarkdownDescription: "Ports associated with the node.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"uuid": schema.StringAttribute{
+							MarkdownDescription: "The UUID of the port.",
+							Computed:            true,
+						},
+						"mac_address": schema.StringAttribute{
+							MarkdownDescription: "The MAC address of the port.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *NodeDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	lookups := make([]path.Expression, 0, len(nodeLookupAttributes))
+	for _, name := range nodeLookupAttributes {
+		lookups = append(lookups, path.MatchRoot(name))
+	}
+
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(lookups...),
+		datasourcevalidator.RequiredTogether(path.MatchRoot("namespace"), path.MatchRoot("name")),
+	}
+}
+
+func (d *NodeDataSource) Configure(
+	ctx context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*Meta)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf(
+				"Expected *Clients, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+		return
+	}
+
+	d.meta = clients
+}
+
+func (d *NodeDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var lookup nodeLookup
+	for name, value := range map[string]*types.String{
+		"uuid":          &lookup.UUID,
+		"name":          &lookup.Name,
+		"namespace":     &lookup.Namespace,
+		"instance_uuid": &lookup.InstanceUUID,
+		"mac_address":   &lookup.MACAddress,
+	} {
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name), value)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nodeUUID, err := lookupNodeUUID(ctx, d.meta.Client, lookup)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Find Node",
+			fmt.Sprintf("Unable to find node: %s", err),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Found node", map[string]any{"uuid": nodeUUID})
+
+	var nodeSchema resource.SchemaResponse
+	NewNodeResource().Schema(ctx, resource.SchemaRequest{}, &nodeSchema)
+	nodeTypes := nodeSchema.Schema.Type().(attr.TypeWithAttributeTypes).AttributeTypes()
+
+	// Read the node the same way the ironic_node resource does, the
+	// collections it does not read still need their type
+	model := NodeResourceModel{
+		ID:         types.StringValue(nodeUUID),
+		Traits:     types.SetValueMust(types.StringType, []attr.Value{}),
+		CleanSteps: types.ListNull(nodeTypes["clean_steps"].(types.ListType).ElemType),
+		Timeouts: timeouts.Value{
+			Object: types.ObjectNull(nodeTypes["timeouts"].(attr.TypeWithAttributeTypes).AttributeTypes()),
+		},
+	}
+	(&NodeResource{meta: d.meta}).readNodeData(ctx, &model, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nodePorts, err := listNodePorts(ctx, d.meta.Client, nodeUUID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to List Node Ports",
+			fmt.Sprintf("Unable to list the ports of node %s: %s", nodeUUID, err),
+		)
+		return
+	}
+	model.Ports = nodePorts
+
+	nodeValue, diags := types.ObjectValueFrom(ctx, nodeTypes, model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Copy the attributes the data source shares with the resource
+	for name, value := range nodeValue.Attributes() {
+		if name == "timeouts" || slices.Contains(nodeResourceOnlyAttributes, name) {
+			continue
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(name), value)...)
+	}
+
+	// Keep the lookup as configured, a name may include its namespace
+	if !lookup.Name.IsNull() {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), lookup.Name)...)
+	}
+	if !lookup.Namespace.IsNull() {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("namespace"), lookup.Namespace)...)
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("uuid"), nodeUUID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("mac_address"), lookup.MACAddress)...)
+}
+
+// lookupNodeUUID returns the UUID of the node matching the lookup.
+func lookupNodeUUID(
+	ctx context.Context,
+	client *gophercloud.ServiceClient,
+	lookup nodeLookup,
+) (string, error) {
+	switch {
+	case !lookup.UUID.IsNull():
+		return lookup.UUID.ValueString(), nil
+
+	case !lookup.Name.IsNull():
+		// Nodes managed by metal3 are named namespace~name
+		name := lookup.Name.ValueString()
+		if !lookup.Namespace.IsNull() && lookup.Namespace.ValueString() != "" {
+			name = lookup.Namespace.ValueString() + "~" + name
+		}
+		node, err := nodes.Get(ctx, client, name).Extract()
+		if err != nil {
+			return "", fmt.Errorf("could not get node %s: %w", name, err)
+		}
+		return node.UUID, nil
+
+	case !lookup.InstanceUUID.IsNull():
+		instanceUUID := lookup.InstanceUUID.ValueString()
+		page, err := nodes.List(client, nodes.ListOpts{InstanceUUID: instanceUUID}).AllPages(ctx)
+		if err != nil {
+			return "", fmt.Errorf("could not list nodes: %w", err)
+		}
+		found, err := nodes.ExtractNodes(page)
+		if err != nil {
+			return "", fmt.Errorf("could not list nodes: %w", err)
+		}
+		if len(found) == 0 {
+			return "", fmt.Errorf("no node has instance UUID %s", instanceUUID)
+		}
+		return found[0].UUID, nil
+
+	case !lookup.MACAddress.IsNull():
+		address := lookup.MACAddress.ValueString()
+		page, err := ports.List(client, ports.ListOpts{Address: address}).AllPages(ctx)
+		if err != nil {
+			return "", fmt.Errorf("could not list ports: %w", err)
+		}
+		found, err := ports.ExtractPorts(page)
+		if err != nil {
+			return "", fmt.Errorf("could not list ports: %w", err)
+		}
+		if len(found) == 0 {
+			return "", fmt.Errorf("no port has MAC address %s", address)
+		}
+		return found[0].NodeUUID, nil
+	}
+
+	return "", fmt.Errorf("one of uuid, name, instance_uuid or mac_address must be set")
+}
+
+// listNodePorts returns the ports of a node.
+func listNodePorts(
+	ctx context.Context,
+	client *gophercloud.ServiceClient,
+	nodeUUID string,
+) ([]NodePortModel, error) {
+	page, err := ports.List(client, ports.ListOpts{NodeUUID: nodeUUID}).AllPages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	found, err := ports.ExtractPorts(page)
+	if err != nil {
+		return nil, err
+	}
+
+	nodePorts := make([]NodePortModel, 0, len(found))
+	for _, port := range found {
+		nodePorts = append(nodePorts, NodePortModel{
+			UUID:       types.StringValue(port.UUID),
+			MACAddress: types.StringValue(port.Address),
+		})
+	}
+	return nodePorts, nil
+}
@@ -0,0 +1,213 @@
+package ironic
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/noauth"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	th "github.com/metal3-community/terraform-provider-ironic/testhelper"
+)
+
+func TestNodeDataSourceRead(t *testing.T) {
+	node := map[string]any{
+		"uuid":            "1be26c0b-03f2-4d2e-ae87-c02d7f33c123",
+		"name":            "metal3~worker-0",
+		"driver":          "redfish",
+		"instance_uuid":   "9d4ed5b1-0b7a-4e4d-8b3f-5b1c0c5f8d11",
+		"provision_state": "active",
+		"power_state":     "power on",
+		"automated_clean": true,
+		"properties":      map[string]any{"cpu_arch": "x86_64"},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/nodes/", func(w http.ResponseWriter, r *http.Request) {
+		ident := r.URL.Path[len("/v1/nodes/"):]
+		if ident != node["uuid"] && ident != node["name"] {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(node)
+	})
+	mux.HandleFunc("/v1/nodes", func(w http.ResponseWriter, r *http.Request) {
+		found := []any{}
+		if r.URL.Query().Get("instance_uuid") == node["instance_uuid"] {
+			found = append(found, node)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"nodes": found})
+	})
+	mux.HandleFunc("/v1/ports", func(w http.ResponseWriter, r *http.Request) {
+		port := map[string]any{
+			"uuid":      "c4b5e3a2-6c1e-4b59-9d0e-3f3b8c1f2a10",
+			"address":   "52:54:00:cf:2d:31",
+			"node_uuid": node["uuid"],
+		}
+		found := []any{}
+		query := r.URL.Query()
+		if query.Get("node_uuid") == node["uuid"] || query.Get("address") == port["address"] {
+			found = append(found, port)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"ports": found})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := noauth.NewBareMetalNoAuth(noauth.EndpointOpts{
+		IronicEndpoint: server.URL + "/v1",
+	})
+	th.AssertNoError(t, err)
+
+	ctx := context.Background()
+	d := &NodeDataSource{meta: &Meta{Client: client}}
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	if diags := schemaResp.Schema.ValidateImplementation(ctx); diags.HasError() {
+		t.Fatalf("invalid schema: %v", diags)
+	}
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	tests := []struct {
+		name   string
+		config map[string]string
+		err    string
+	}{
+		{name: "uuid", config: map[string]string{"uuid": "1be26c0b-03f2-4d2e-ae87-c02d7f33c123"}},
+		{name: "namespaced name", config: map[string]string{"name": "worker-0", "namespace": "metal3"}},
+		{name: "full name", config: map[string]string{"name": "metal3~worker-0"}},
+		{name: "instance uuid", config: map[string]string{"instance_uuid": "9d4ed5b1-0b7a-4e4d-8b3f-5b1c0c5f8d11"}},
+		{name: "mac address", config: map[string]string{"mac_address": "52:54:00:cf:2d:31"}},
+		{name: "unknown mac address", config: map[string]string{"mac_address": "52:54:00:cf:2d:32"}, err: "no port has MAC address"},
+		{name: "unknown name", config: map[string]string{"name": "worker-1"}, err: "could not get node"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			values := map[string]tftypes.Value{}
+			for name, attributeType := range objectType.AttributeTypes {
+				values[name] = tftypes.NewValue(attributeType, nil)
+			}
+			for name, value := range test.config {
+				values[name] = tftypes.NewValue(tftypes.String, value)
+			}
+
+			req := datasource.ReadRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+			}
+			resp := datasource.ReadResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+			}
+			d.Read(ctx, req, &resp)
+
+			if test.err != "" {
+				if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), test.err) {
+					t.Errorf("expected error containing %q, got %v", test.err, resp.Diagnostics)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+			}
+
+			for attribute, expected := range map[string]string{
+				"uuid":            "1be26c0b-03f2-4d2e-ae87-c02d7f33c123",
+				"id":              "1be26c0b-03f2-4d2e-ae87-c02d7f33c123",
+				"full_name":       "metal3~worker-0",
+				"namespace":       "metal3",
+				"driver":          "redfish",
+				"provision_state": "active",
+			} {
+				var value types.String
+				resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root(attribute), &value)...)
+				if value.ValueString() != expected {
+					t.Errorf("expected %s to be %q, got %q", attribute, expected, value.ValueString())
+				}
+			}
+
+			var ports []NodePortModel
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("ports"), &ports)...)
+			if len(ports) != 1 || ports[0].MACAddress.ValueString() != "52:54:00:cf:2d:31" {
+				t.Errorf("unexpected ports: %v", ports)
+			}
+			if resp.Diagnostics.HasError() {
+				t.Errorf("unexpected errors: %v", resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestDataSourceSchemasMatchResources(t *testing.T) {
+	tests := []struct {
+		name       string
+		dataSource datasource.DataSource
+		resource   resource.Resource
+		skip       []string
+	}{
+		{
+			name:       "ironic_node",
+			dataSource: NewNodeDataSource(),
+			resource:   NewNodeResource(),
+			skip:       append([]string{"timeouts"}, nodeResourceOnlyAttributes...),
+		},
+		{
+			name:       "ironic_port",
+			dataSource: NewPortDataSource(),
+			resource:   NewPortV1Resource(),
+		},
+		{
+			name:       "ironic_port_group",
+			dataSource: NewPortGroupDataSource(),
+			resource:   NewPortGroupResource(),
+		},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var dataSourceSchema datasource.SchemaResponse
+			tt.dataSource.Schema(ctx, datasource.SchemaRequest{}, &dataSourceSchema)
+			if diags := dataSourceSchema.Schema.ValidateImplementation(ctx); diags.HasError() {
+				t.Fatalf("invalid schema: %v", diags)
+			}
+
+			var resourceSchema resource.SchemaResponse
+			tt.resource.Schema(ctx, resource.SchemaRequest{}, &resourceSchema)
+
+			// The data source is read through the resource model, so every
+			// attribute the resource reads must exist with the same type
+			for name, attribute := range resourceSchema.Schema.Attributes {
+				if slices.Contains(tt.skip, name) {
+					continue
+				}
+				dataSourceAttribute, ok := dataSourceSchema.Schema.Attributes[name]
+				if !ok {
+					t.Errorf("attribute %s is missing from the data source", name)
+					continue
+				}
+				if !dataSourceAttribute.IsComputed() {
+					t.Errorf("attribute %s is not computed in the data source", name)
+				}
+				if !dataSourceAttribute.GetType().Equal(attribute.GetType()) {
+					t.Errorf(
+						"attribute %s is %s in the data source, %s in the resource",
+						name, dataSourceAttribute.GetType(), attribute.GetType(),
+					)
+				}
+			}
+		})
+	}
+}
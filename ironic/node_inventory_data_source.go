@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetalintrospection/v1/introspection"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -334,8 +335,28 @@ func (d *NodeInventoryDataSource) Read(
 	tflog.Debug(ctx, "Getting inventory data for node", map[string]any{"uuid": nodeUUID})
 	var inventoryData *models.InventoryData
 
-	// Get inventory data using nodes.GetInventory
-	err := nodes.GetInventory(ctx, d.meta.Client, nodeUUID).ExtractInto(&inventoryData)
+	// Nodes inspected by a separate Ironic Inspector keep their inventory
+	// there, otherwise use nodes.GetInventory
+	useInspector := false
+	if d.meta.Inspector != nil {
+		node, err := nodes.Get(ctx, d.meta.Client, nodeUUID).Extract()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Get Node",
+				fmt.Sprintf("Unable to get node %s: %s", nodeUUID, err),
+			)
+			return
+		}
+		useInspector = d.meta.usesInspector(node)
+	}
+
+	var err error
+	if useInspector {
+		tflog.Debug(ctx, "Getting inventory data from Ironic Inspector", map[string]any{"uuid": nodeUUID})
+		err = introspection.GetIntrospectionData(ctx, d.meta.Inspector, nodeUUID).ExtractInto(&inventoryData)
+	} else {
+		err = nodes.GetInventory(ctx, d.meta.Client, nodeUUID).ExtractInto(&inventoryData)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Get Node Inventory",
@@ -0,0 +1,526 @@
+package ironic
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/metal3-community/terraform-provider-ironic/ironic/util"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &NodeRAIDConfigResource{}
+	_ resource.ResourceWithConfigure   = &NodeRAIDConfigResource{}
+	_ resource.ResourceWithImportState = &NodeRAIDConfigResource{}
+	_ resource.ResourceWithModifyPlan  = &NodeRAIDConfigResource{}
+)
+
+// nodeRAIDConfigMicroversions lists the microversions needed by RAID
+// configuration, which is applied with manual cleaning.
+var nodeRAIDConfigMicroversions = []microversionRequirement{
+	{Version: "1.15"},
+}
+
+// NodeRAIDConfigResource manages the RAID configuration of an Ironic node.
+type NodeRAIDConfigResource struct {
+	meta *Meta
+}
+
+// NodeRAIDConfigResourceModel describes the resource data model.
+type NodeRAIDConfigResourceModel struct {
+	ID           types.String   `tfsdk:"id"`
+	NodeUUID     types.String   `tfsdk:"node_uuid"`
+	LogicalDisks types.List     `tfsdk:"logical_disks"`
+	RAIDConfig   types.Dynamic  `tfsdk:"raid_config"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
+
+type logicalDiskModel struct {
+	SizeGB        types.Int64  `tfsdk:"size_gb"`
+	RAIDLevel     types.String `tfsdk:"raid_level"`
+	IsRootVolume  types.Bool   `tfsdk:"is_root_volume"`
+	Controller    types.String `tfsdk:"controller"`
+	PhysicalDisks types.List   `tfsdk:"physical_disks"`
+	VolumeName    types.String `tfsdk:"volume_name"`
+}
+
+var logicalDiskAttrTypes = map[string]attr.Type{
+	"size_gb":        types.Int64Type,
+	"raid_level":     types.StringType,
+	"is_root_volume": types.BoolType,
+	"controller":     types.StringType,
+	"physical_disks": types.ListType{ElemType: types.StringType},
+	"volume_name":    types.StringType,
+}
+
+func NewNodeRAIDConfigResource() resource.Resource {
+	return &NodeRAIDConfigResource{}
+}
+
+func (r *NodeRAIDConfigResource) Metadata(
+	ctx context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_node_raid_config"
+}
+
+func (r *NodeRAIDConfigResource) Schema(
+	ctx context.Context,
+	req resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the RAID configuration of an Ironic node. The logical disks are set as the target RAID configuration of the node, then built with the `raid.delete_configuration` and `raid.create_configuration` clean steps, which requires the node to be in the `enroll`, `manageable` or `available` state; available nodes are made available again afterwards. Destroying the resource clears the target RAID configuration but leaves the volumes in place.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The UUID of the node.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"node_uuid": schema.StringAttribute{
+				MarkdownDescription: "The UUID of the node.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"logical_disks": schema.ListNestedAttribute{
+				MarkdownDescription: "The logical disks to build on the node.",
+				Required:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"size_gb": schema.Int64Attribute{
+							MarkdownDescription: "Size of the logical disk in GiB. All the remaining space is used when unset.",
+							Optional:            true,
+							Validators: []validator.Int64{
+								int64validator.AtLeast(1),
+							},
+						},
+						"raid_level": schema.StringAttribute{
+							MarkdownDescription: "RAID level of the logical disk, one of `0`, `1`, `2`, `5`, `6`, `1+0`, `5+0`, `6+0` or `JBOD`.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(
+									string(nodes.RAID0),
+									string(nodes.RAID1),
+									string(nodes.RAID2),
+									string(nodes.RAID5),
+									string(nodes.RAID6),
+									string(nodes.RAID10),
+									string(nodes.RAID50),
+									string(nodes.RAID60),
+									string(nodes.JBOD),
+								),
+							},
+						},
+						"is_root_volume": schema.BoolAttribute{
+							MarkdownDescription: "Whether the logical disk is the root volume. At most one logical disk can be the root volume.",
+							Optional:            true,
+						},
+						"controller": schema.StringAttribute{
+							MarkdownDescription: "Name of the controller, as read by the RAID interface.",
+							Optional:            true,
+						},
+						"physical_disks": schema.ListAttribute{
+							MarkdownDescription: "Physical disks to use, as read by the RAID interface.",
+							ElementType:         types.StringType,
+							Optional:            true,
+						},
+						"volume_name": schema.StringAttribute{
+							MarkdownDescription: "Name of the volume.",
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"raid_config": schema.DynamicAttribute{
+				MarkdownDescription: "The current RAID configuration of the node, as reported by Ironic.",
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
+	}
+}
+
+func (r *NodeRAIDConfigResource) Configure(
+	ctx context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*Meta)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf(
+				"Expected *Clients, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+		return
+	}
+
+	r.meta = clients
+}
+
+// ModifyPlan reports when the Ironic API microversion is too old for RAID
+// configuration.
+func (r *NodeRAIDConfigResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	// Nothing to check on destroy, or before the provider is configured.
+	if req.Plan.Raw.IsNull() || r.meta == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(
+		r.meta.validateMicroversions(ctx, "ironic_node_raid_config", req.Config, nodeRAIDConfigMicroversions)...,
+	)
+}
+
+func (r *NodeRAIDConfigResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan NodeRAIDConfigResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := operationContext(ctx, createTimeout)
+	defer cancel()
+
+	plan.ID = plan.NodeUUID
+
+	r.applyRAIDConfig(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *NodeRAIDConfigResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state NodeRAIDConfigResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	node, err := nodes.Get(ctx, r.meta.Client, state.ID.ValueString()).Extract()
+	if err != nil {
+		if gophercloud.ResponseCodeIs(err, http.StatusNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error reading node",
+			fmt.Sprintf("Could not read node %s: %s", state.ID.ValueString(), err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(readNodeRAIDConfigData(ctx, &state, node)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *NodeRAIDConfigResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var plan, state NodeRAIDConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only rebuild the volumes when the logical disks changed
+	if plan.LogicalDisks.Equal(state.LogicalDisks) {
+		plan.RAIDConfig = state.RAIDConfig
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := operationContext(ctx, updateTimeout)
+	defer cancel()
+
+	r.applyRAIDConfig(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *NodeRAIDConfigResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var state NodeRAIDConfigResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The volumes stay, only the target configuration is cleared
+	err := nodes.SetRAIDConfig(ctx, r.meta.Client, state.ID.ValueString(), nodes.RAIDConfigOpts{
+		LogicalDisks: []nodes.LogicalDisk{},
+	}).ExtractErr()
+	if err != nil && !gophercloud.ResponseCodeIs(err, http.StatusNotFound) {
+		resp.Diagnostics.AddError(
+			"Error clearing RAID configuration",
+			fmt.Sprintf("Could not clear the target RAID configuration of node %s: %s", state.ID.ValueString(), err),
+		)
+	}
+}
+
+func (r *NodeRAIDConfigResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// applyRAIDConfig sets the target RAID configuration of the node and builds
+// it with manual cleaning.
+func (r *NodeRAIDConfigResource) applyRAIDConfig(
+	ctx context.Context,
+	model *NodeRAIDConfigResourceModel,
+	diagnostics *diag.Diagnostics,
+) {
+	nodeUUID := model.NodeUUID.ValueString()
+
+	logicalDisks, diags := buildLogicalDisks(ctx, model.LogicalDisks)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Applying RAID configuration", map[string]any{
+		"node_id":       nodeUUID,
+		"logical_disks": len(logicalDisks),
+	})
+
+	err := nodes.SetRAIDConfig(ctx, r.meta.Client, nodeUUID, nodes.RAIDConfigOpts{
+		LogicalDisks: logicalDisks,
+	}).ExtractErr()
+	if err != nil {
+		diagnostics.AddError(
+			"Error setting RAID configuration",
+			fmt.Sprintf("Could not set the target RAID configuration of node %s: %s", nodeUUID, err),
+		)
+		return
+	}
+
+	cleanSteps := []nodes.CleanStep{
+		{Interface: nodes.InterfaceRAID, Step: "delete_configuration"},
+		{Interface: nodes.InterfaceRAID, Step: "create_configuration"},
+	}
+	if err := runManualClean(ctx, r.meta.Client, nodeUUID, cleanSteps); err != nil {
+		diagnostics.AddError(
+			"Error applying RAID configuration",
+			fmt.Sprintf("Could not apply the RAID configuration to node %s: %s", nodeUUID, err),
+		)
+		return
+	}
+
+	node, err := nodes.Get(ctx, r.meta.Client, nodeUUID).Extract()
+	if err != nil {
+		diagnostics.AddError(
+			"Error reading node",
+			fmt.Sprintf("Could not read node %s: %s", nodeUUID, err),
+		)
+		return
+	}
+
+	raidConfig, err := util.MapToDynamic(ctx, node.RAIDConfig)
+	if err != nil {
+		diagnostics.AddError(
+			"Error converting RAID configuration",
+			fmt.Sprintf("Could not convert the RAID configuration of node %s: %s", nodeUUID, err),
+		)
+		return
+	}
+	model.RAIDConfig = raidConfig
+}
+
+// buildLogicalDisks converts the logical_disks attribute to the Ironic
+// format. Sizes left unset use the remaining space.
+func buildLogicalDisks(
+	ctx context.Context,
+	list types.List,
+) ([]nodes.LogicalDisk, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var models []logicalDiskModel
+	diags.Append(list.ElementsAs(ctx, &models, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	logicalDisks := make([]nodes.LogicalDisk, len(models))
+	for i, model := range models {
+		logicalDisk := nodes.LogicalDisk{
+			RAIDLevel:    nodes.RAIDLevel(model.RAIDLevel.ValueString()),
+			VolumeName:   model.VolumeName.ValueString(),
+			IsRootVolume: model.IsRootVolume.ValueBoolPointer(),
+			Controller:   model.Controller.ValueString(),
+		}
+		if !model.SizeGB.IsNull() {
+			size := int(model.SizeGB.ValueInt64())
+			logicalDisk.SizeGB = &size
+		}
+		if !model.PhysicalDisks.IsNull() {
+			var physicalDisks []string
+			diags.Append(model.PhysicalDisks.ElementsAs(ctx, &physicalDisks, false)...)
+			for _, disk := range physicalDisks {
+				logicalDisk.PhysicalDisks = append(logicalDisk.PhysicalDisks, disk)
+			}
+		}
+		logicalDisks[i] = logicalDisk
+	}
+	return logicalDisks, diags
+}
+
+// readNodeRAIDConfigData refreshes the model from the node. The logical disks
+// come from the target RAID configuration, so changes made outside of
+// Terraform show up in the plan.
+func readNodeRAIDConfigData(
+	ctx context.Context,
+	model *NodeRAIDConfigResourceModel,
+	node *nodes.Node,
+) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.ID = types.StringValue(node.UUID)
+	model.NodeUUID = types.StringValue(node.UUID)
+
+	raidConfig, err := util.MapToDynamic(ctx, node.RAIDConfig)
+	if err != nil {
+		diags.AddError(
+			"Error converting RAID configuration",
+			fmt.Sprintf("Could not convert the RAID configuration of node %s: %s", node.UUID, err),
+		)
+		return diags
+	}
+	model.RAIDConfig = raidConfig
+
+	targetDisks, _ := node.TargetRAIDConfig["logical_disks"].([]any)
+	models := make([]logicalDiskModel, 0, len(targetDisks))
+	for _, item := range targetDisks {
+		disk, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		logicalDisk := logicalDiskModel{
+			SizeGB:        types.Int64Null(),
+			RAIDLevel:     types.StringValue(fmt.Sprint(disk["raid_level"])),
+			IsRootVolume:  types.BoolNull(),
+			Controller:    types.StringNull(),
+			PhysicalDisks: types.ListNull(types.StringType),
+			VolumeName:    types.StringNull(),
+		}
+		// Sizes are either a number of GiB or MAX
+		if size, ok := disk["size_gb"].(float64); ok {
+			logicalDisk.SizeGB = types.Int64Value(int64(size))
+		}
+		if isRoot, ok := disk["is_root_volume"].(bool); ok {
+			logicalDisk.IsRootVolume = types.BoolValue(isRoot)
+		}
+		if controller, ok := disk["controller"].(string); ok {
+			logicalDisk.Controller = types.StringValue(controller)
+		}
+		if volumeName, ok := disk["volume_name"].(string); ok {
+			logicalDisk.VolumeName = types.StringValue(volumeName)
+		}
+		if physicalDisks, ok := disk["physical_disks"].([]any); ok {
+			names := make([]string, len(physicalDisks))
+			for i, physicalDisk := range physicalDisks {
+				names[i] = fmt.Sprint(physicalDisk)
+			}
+			var d diag.Diagnostics
+			logicalDisk.PhysicalDisks, d = types.ListValueFrom(ctx, types.StringType, names)
+			diags.Append(d...)
+		}
+		models = append(models, logicalDisk)
+	}
+
+	var d diag.Diagnostics
+	model.LogicalDisks, d = types.ListValueFrom(
+		ctx,
+		types.ObjectType{AttrTypes: logicalDiskAttrTypes},
+		models,
+	)
+	diags.Append(d...)
+	return diags
+}
@@ -0,0 +1,148 @@
+package ironic
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/noauth"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	th "github.com/metal3-community/terraform-provider-ironic/testhelper"
+)
+
+// fakeRAIDIronic is a fake Ironic that builds the target RAID configuration
+// of a node when it is cleaned.
+type fakeRAIDIronic struct {
+	mu             sync.Mutex
+	provisionState string
+	target         map[string]any
+	current        map[string]any
+	cleanSteps     []any
+}
+
+func (f *fakeRAIDIronic) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var body map[string]any
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+	}
+
+	switch r.Method + " " + r.URL.Path {
+	case "GET /v1/nodes/node":
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"uuid":               "node",
+			"provision_state":    f.provisionState,
+			"raid_config":        f.current,
+			"target_raid_config": f.target,
+		})
+	case "PUT /v1/nodes/node/states/raid":
+		f.target = body
+		w.WriteHeader(http.StatusNoContent)
+	case "PUT /v1/nodes/node/states/provision":
+		switch body["target"] {
+		case "manage":
+			f.provisionState = "manageable"
+		case "provide":
+			f.provisionState = "available"
+		case "clean":
+			f.cleanSteps = body["clean_steps"].([]any)
+			f.current = f.target
+			f.provisionState = "manageable"
+		}
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestApplyRAIDConfig(t *testing.T) {
+	defer func(interval time.Duration) { workflowPollInterval = interval }(workflowPollInterval)
+	workflowPollInterval = 10 * time.Millisecond
+
+	ctx := context.Background()
+
+	fake := &fakeRAIDIronic{provisionState: "available"}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client, err := noauth.NewBareMetalNoAuth(noauth.EndpointOpts{
+		IronicEndpoint: server.URL + "/v1",
+	})
+	th.AssertNoError(t, err)
+
+	physicalDisks, _ := types.ListValueFrom(ctx, types.StringType, []string{"Disk.0", "Disk.1"})
+	logicalDisks, diags := types.ListValue(
+		types.ObjectType{AttrTypes: logicalDiskAttrTypes},
+		[]attr.Value{
+			types.ObjectValueMust(logicalDiskAttrTypes, map[string]attr.Value{
+				"size_gb":        types.Int64Value(100),
+				"raid_level":     types.StringValue("1"),
+				"is_root_volume": types.BoolValue(true),
+				"controller":     types.StringValue("RAID.Integrated.1-1"),
+				"physical_disks": physicalDisks,
+				"volume_name":    types.StringValue("root"),
+			}),
+			types.ObjectValueMust(logicalDiskAttrTypes, map[string]attr.Value{
+				"size_gb":        types.Int64Null(),
+				"raid_level":     types.StringValue("0"),
+				"is_root_volume": types.BoolNull(),
+				"controller":     types.StringNull(),
+				"physical_disks": types.ListNull(types.StringType),
+				"volume_name":    types.StringNull(),
+			}),
+		},
+	)
+	if diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags)
+	}
+
+	r := &NodeRAIDConfigResource{meta: &Meta{Client: client}}
+	model := &NodeRAIDConfigResourceModel{
+		NodeUUID:     types.StringValue("node"),
+		LogicalDisks: logicalDisks,
+	}
+
+	diags = diag.Diagnostics{}
+	r.applyRAIDConfig(ctx, model, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags)
+	}
+
+	expectedSteps := []any{
+		map[string]any{"interface": "raid", "step": "delete_configuration"},
+		map[string]any{"interface": "raid", "step": "create_configuration"},
+	}
+	if !reflect.DeepEqual(fake.cleanSteps, expectedSteps) {
+		t.Errorf("unexpected clean steps: %v", fake.cleanSteps)
+	}
+	if fake.provisionState != "available" {
+		t.Errorf("expected node to be available again, got %s", fake.provisionState)
+	}
+	if model.RAIDConfig.IsNull() || model.RAIDConfig.IsUnknown() {
+		t.Errorf("expected the current RAID configuration, got %v", model.RAIDConfig)
+	}
+
+	// Reading the node back must not show a difference
+	node, err := nodes.Get(ctx, client, "node").Extract()
+	th.AssertNoError(t, err)
+
+	state := &NodeRAIDConfigResourceModel{}
+	diags = readNodeRAIDConfigData(ctx, state, node)
+	if diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags)
+	}
+	if !state.LogicalDisks.Equal(logicalDisks) {
+		t.Errorf("expected logical disks %v, got %v", logicalDisks, state.LogicalDisks)
+	}
+}
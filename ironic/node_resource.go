@@ -4,29 +4,36 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/dynamicplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/metal3-community/terraform-provider-ironic/ironic/util"
 )
 
 const (
-	DefaultAvailable   = true
-	DefaultManage      = true
-	DefaultInspect     = true
-	DefaultClean       = false
-	DefaultMaintenance = false
+	DefaultAvailable = true
+	DefaultManage    = true
+	DefaultInspect   = true
+	DefaultClean     = false
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -34,8 +41,28 @@ var (
 	_ resource.Resource                = &NodeResource{}
 	_ resource.ResourceWithConfigure   = &NodeResource{}
 	_ resource.ResourceWithImportState = &NodeResource{}
+	_ resource.ResourceWithModifyPlan  = &NodeResource{}
 )
 
+// nodeMicroversions lists the node attributes that need a newer microversion
+// than the base Ironic API.
+var nodeMicroversions = []microversionRequirement{
+	{Attribute: "power_state_timeout", Version: "1.27"},
+	{Attribute: "vendor_interface", Version: "1.31"},
+	{Attribute: "raid_interface", Version: "1.31"},
+	{Attribute: "storage_interface", Version: "1.33"},
+	{Attribute: "traits", Version: "1.37"},
+	{Attribute: "rescue_interface", Version: "1.38"},
+	{Attribute: "bios_interface", Version: "1.40"},
+	{Attribute: "conductor_group", Version: "1.46"},
+	{Attribute: "automated_clean", Version: "1.47"},
+	{Attribute: "protected", Version: "1.48"},
+	{Attribute: "owner", Version: "1.50"},
+	{Attribute: "lessee", Version: "1.65"},
+	{Attribute: "disable_ramdisk", Version: "1.70"},
+	{Attribute: "firmware_interface", Version: "1.86"},
+}
+
 // NodeResource defines the resource implementation.
 type NodeResource struct {
 	meta *Meta
@@ -55,11 +82,13 @@ type NodeResourceModel struct {
 	Chassis              types.String      `tfsdk:"chassis_uuid"`
 	Clean                types.Bool        `tfsdk:"clean"`
 	CleanStep            types.Dynamic     `tfsdk:"clean_step"`
+	CleanSteps           types.List        `tfsdk:"clean_steps"`
 	Conductor            types.String      `tfsdk:"conductor"`
 	ConductorGroup       types.String      `tfsdk:"conductor_group"`
 	ConsoleInterface     types.String      `tfsdk:"console_interface"`
 	DeployInterface      types.String      `tfsdk:"deploy_interface"`
 	DeployStep           types.Dynamic     `tfsdk:"deploy_step"`
+	DisableRamdisk       types.Bool        `tfsdk:"disable_ramdisk"`
 	Driver               types.String      `tfsdk:"driver"`
 	DriverInfo           types.Dynamic     `tfsdk:"driver_info"`
 	ExtraData            types.Dynamic     `tfsdk:"extra"`
@@ -80,6 +109,7 @@ type NodeResourceModel struct {
 	Ports                []NodePortModel   `tfsdk:"ports"`
 	PowerInterface       types.String      `tfsdk:"power_interface"`
 	PowerState           types.String      `tfsdk:"power_state"`
+	PowerStateTimeout    types.Int64       `tfsdk:"power_state_timeout"`
 	Properties           types.Dynamic     `tfsdk:"properties"`
 	Protected            types.Bool        `tfsdk:"protected"`
 	ProvisionState       types.String      `tfsdk:"provision_state"`
@@ -89,12 +119,14 @@ type NodeResourceModel struct {
 	StorageInterface     types.String      `tfsdk:"storage_interface"`
 	TargetPowerState     types.String      `tfsdk:"target_power_state"`
 	TargetProvisionState types.String      `tfsdk:"target_provision_state"`
+	Traits               types.Set         `tfsdk:"traits"`
 	VendorInterface      types.String      `tfsdk:"vendor_interface"`
 	Updated              timetypes.RFC3339 `tfsdk:"updated_at"`
 	Created              timetypes.RFC3339 `tfsdk:"created_at"`
 	InspectionStarted    timetypes.RFC3339 `tfsdk:"inspection_started_at"`
 	InspectionFinished   timetypes.RFC3339 `tfsdk:"inspection_finished_at"`
 	ProvisionUpdated     timetypes.RFC3339 `tfsdk:"provision_updated_at"`
+	Timeouts             timeouts.Value    `tfsdk:"timeouts"`
 }
 
 // NodePortModel describes the port data model within the node.
@@ -255,12 +287,20 @@ func (r *NodeResource) Schema(
 				Default:             booldefault.StaticBool(false),
 			},
 			"maintenance": schema.BoolAttribute{
-				MarkdownDescription: "Indicates whether the node is in maintenance mode.",
+				MarkdownDescription: "Whether the node is in maintenance mode. Maintenance is left before and entered after any requested provision state change. When not set, the maintenance mode operators or Ironic put the node in is left alone.",
+				Optional:            true,
 				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"maintenance_reason": schema.StringAttribute{
-				MarkdownDescription: "The reason for putting the node in maintenance mode.",
+				MarkdownDescription: "The reason for putting the node in maintenance mode, only allowed when `maintenance` is true.",
+				Optional:            true,
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"properties": schema.DynamicAttribute{
 				MarkdownDescription: "The properties of the node.",
@@ -324,12 +364,29 @@ func (r *NodeResource) Schema(
 				},
 			},
 			"target_power_state": schema.StringAttribute{
-				MarkdownDescription: "The target power state of the node.",
+				MarkdownDescription: "The power state the node should be in, one of `power on`, `power off`, `rebooting`, `soft power off` or `soft rebooting`. The provider waits until the node reaches it. When not set, this is the power state Ironic is currently moving the node to, if any.",
+				Optional:            true,
 				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(nodes.PowerOn),
+						string(nodes.PowerOff),
+						string(nodes.Rebooting),
+						string(nodes.SoftPowerOff),
+						string(nodes.SoftRebooting),
+					),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"power_state_timeout": schema.Int64Attribute{
+				MarkdownDescription: "Timeout in seconds for Ironic to complete a change of `target_power_state`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
 			"last_error": schema.StringAttribute{
 				MarkdownDescription: "The last error message for the node.",
 				Computed:            true,
@@ -360,6 +417,20 @@ func (r *NodeResource) Schema(
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"traits": schema.SetAttribute{
+				MarkdownDescription: "Traits of the node, matched by allocations. Either standard traits or custom ones prefixed with `CUSTOM_`. When set, the node has exactly these traits, replacing those added by inspection or other tools. When not set, they are left alone.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(
+						stringvalidator.LengthBetween(1, 255),
+						stringvalidator.RegexMatches(
+							nodeTraitRegexp,
+							"must only contain upper case letters, digits and underscores",
+						),
+					),
+				},
+			},
 			"conductor": schema.StringAttribute{
 				MarkdownDescription: "The conductor managing the node.",
 				Computed:            true,
@@ -456,11 +527,20 @@ func (r *NodeResource) Schema(
 				},
 			},
 			"clean": schema.BoolAttribute{
-				MarkdownDescription: "Trigger node cleaning. When set to true, the node will be moved to the cleaning state.",
+				MarkdownDescription: "Trigger node cleaning. When set to true, the node will be moved to the cleaning state and the `clean_steps` will be run.",
 				Optional:            true,
 				Computed:            true,
 				Default:             booldefault.StaticBool(DefaultClean),
 			},
+			"clean_steps": schema.ListNestedAttribute{
+				MarkdownDescription: "The steps to run when cleaning the node. Steps with a priority run first, highest priority first, and the others in list order.",
+				Optional:            true,
+				NestedObject:        manualStepObject("clean"),
+			},
+			"disable_ramdisk": schema.BoolAttribute{
+				MarkdownDescription: "Run the clean steps without booting the agent ramdisk, for steps that do not need it.",
+				Optional:            true,
+			},
 			"inspect": schema.BoolAttribute{
 				MarkdownDescription: "Trigger node inspection. When set to true, the node will be moved to the inspection state.",
 				Optional:            true,
@@ -496,6 +576,11 @@ func (r *NodeResource) Schema(
 					},
 				},
 			},
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
@@ -524,6 +609,106 @@ func (r *NodeResource) Configure(
 	r.meta = clients
 }
 
+// ModifyPlan reports attributes that need a newer Ironic API microversion
+// than the provider is using or that cannot be changed in the current
+// provision state, validates the maintenance reason, and marks
+// the attributes Ironic changes along with maintenance and power state
+// changes as unknown.
+func (r *NodeResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	// Nothing to do on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var targetPowerState types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("target_power_state"), &targetPowerState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.meta != nil {
+		resp.Diagnostics.Append(
+			r.meta.validateMicroversions(ctx, "ironic_node", req.Config, nodeMicroversions)...,
+		)
+		if isSoftPowerTarget(targetPowerState.ValueString()) {
+			if d := r.meta.checkMicroversion("Soft power actions", "1.27"); d != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("target_power_state"), d.Summary(), d.Detail())
+			}
+		}
+		resp.Diagnostics.Append(r.validateDriverInfo(ctx, req)...)
+		resp.Diagnostics.Append(r.validateConductorGroup(ctx, req)...)
+	}
+
+	var maintenance, configMaintenance types.Bool
+	var maintenanceReason types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("maintenance"), &maintenance)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("maintenance"), &configMaintenance)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("maintenance_reason"), &maintenanceReason)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var clean types.Bool
+	var cleanSteps types.List
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("clean"), &clean)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("clean_steps"), &cleanSteps)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Ironic refuses manual cleaning without steps
+	if clean.ValueBool() && cleanSteps.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("clean_steps"),
+			"Missing Clean Steps",
+			"clean_steps must be set when clean is true.",
+		)
+		return
+	}
+
+	// Maintenance is only managed when set, so is its reason
+	if !configMaintenance.IsUnknown() && !configMaintenance.ValueBool() && maintenanceReason.ValueString() != "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("maintenance_reason"),
+			"Invalid Attribute Combination",
+			"maintenance_reason can only be set when maintenance is true.",
+		)
+		return
+	}
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var prior NodeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan NodeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateInterfaceUpdates(&plan, &prior)...)
+
+	// Ironic clears the reason when leaving maintenance
+	if maintenanceReason.IsNull() && !maintenance.Equal(prior.Maintenance) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("maintenance_reason"), types.StringUnknown())...)
+	}
+
+	if !targetPowerState.IsUnknown() && !targetPowerState.Equal(prior.TargetPowerState) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("power_state"), types.StringUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("last_error"), types.StringUnknown())...)
+	}
+}
+
 func (r *NodeResource) Create(
 	ctx context.Context,
 	req resource.CreateRequest,
@@ -538,6 +723,24 @@ func (r *NodeResource) Create(
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := operationContext(ctx, createTimeout)
+	defer cancel()
+
+	// Reading the node replaces the target power state and maintenance, keep
+	// the requested ones
+	targetPowerState := plan.TargetPowerState
+	maintenance, maintenanceReason := plan.Maintenance, plan.MaintenanceReason
+	resp.Diagnostics.Append(configuredMaintenance(ctx, req.Config, &maintenance)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Prepare create options
 	createOpts := nodes.CreateOpts{
 		Driver: plan.Driver.ValueString(),
@@ -675,6 +878,40 @@ func (r *NodeResource) Create(
 	// Update plan with computed values
 	plan.ID = types.StringValue(node.UUID)
 
+	if !plan.Traits.IsNull() && !plan.Traits.IsUnknown() {
+		var traits []string
+		resp.Diagnostics.Append(plan.Traits.ElementsAs(ctx, &traits, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if err := setNodeTraits(ctx, r.meta.Client, node.UUID, traits); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("traits"),
+				"Error setting node traits",
+				fmt.Sprintf("Could not set traits of node %s: %s", node.UUID, err),
+			)
+			return
+		}
+	}
+
+	// Creating a node does not accept these fields, set them right after
+	var extraOpts nodes.UpdateOpts
+	for _, field := range []string{"chassis_uuid", "lessee"} {
+		value := nodeStringFields(&plan)[field]
+		if !value.IsNull() && !value.IsUnknown() && value.ValueString() != "" {
+			extraOpts = append(extraOpts, nodeFieldUpdateOp(field, value))
+		}
+	}
+	if len(extraOpts) > 0 {
+		if _, err := nodes.Update(ctx, r.meta.Client, node.UUID, extraOpts).Extract(); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating node",
+				fmt.Sprintf("Could not update node %s: %s", node.UUID, err),
+			)
+			return
+		}
+	}
+
 	// Read the created node to get all computed fields
 	r.readNodeData(ctx, &plan, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
@@ -686,11 +923,17 @@ func (r *NodeResource) Create(
 	resp.Diagnostics.Append(diags...)
 
 	// Handle action attributes
+	plan.Maintenance, plan.MaintenanceReason = maintenance, maintenanceReason
 	r.handleActionAttributes(ctx, &plan, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Handle power state, the state is saved even on failure to record last_error
+	if !targetPowerState.IsNull() && !targetPowerState.IsUnknown() {
+		r.applyTargetPowerState(ctx, &plan, targetPowerState, &resp.Diagnostics)
+	}
+
 	// Set state
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -772,6 +1015,26 @@ func (r *NodeResource) Update(
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := operationContext(ctx, updateTimeout)
+	defer cancel()
+
+	// Reading the node replaces the target power state and maintenance, keep
+	// the requested ones
+	targetPowerState := plan.TargetPowerState
+	maintenance, maintenanceReason := plan.Maintenance, plan.MaintenanceReason
+	resp.Diagnostics.Append(configuredMaintenance(ctx, req.Config, &maintenance)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	powerChanged := !targetPowerState.IsNull() && !targetPowerState.IsUnknown() &&
+		!targetPowerState.Equal(state.TargetPowerState)
+
 	// Prepare update options
 	updateOpts := nodes.UpdateOpts{}
 
@@ -833,6 +1096,14 @@ func (r *NodeResource) Update(
 		}
 	}
 
+	// Handle traits changes
+	if !plan.Traits.Equal(state.Traits) {
+		r.updateNodeTraits(ctx, state.ID.ValueString(), &plan, &state, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// Read the updated node
 	r.readNodeData(ctx, &plan, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
@@ -840,11 +1111,17 @@ func (r *NodeResource) Update(
 	}
 
 	// Handle action attributes
+	plan.Maintenance, plan.MaintenanceReason = maintenance, maintenanceReason
 	r.handleActionAttributes(ctx, &plan, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Handle power state, the state is saved even on failure to record last_error
+	if powerChanged {
+		r.applyTargetPowerState(ctx, &plan, targetPowerState, &resp.Diagnostics)
+	}
+
 	// Set updated state
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -864,6 +1141,15 @@ func (r *NodeResource) Delete(
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := operationContext(ctx, deleteTimeout)
+	defer cancel()
+
 	err := nodes.Delete(ctx, r.meta.Client, state.ID.ValueString()).ExtractErr()
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -948,8 +1234,16 @@ func (r *NodeResource) readNodeData(
 	model.RescueInterface = types.StringValue(node.RescueInterface)
 	model.ResourceClass = types.StringValue(node.ResourceClass)
 	model.StorageInterface = types.StringValue(node.StorageInterface)
-	model.TargetPowerState = types.StringValue(node.TargetPowerState)
+	model.TargetPowerState = readTargetPowerState(model.TargetPowerState, node)
 	model.TargetProvisionState = types.StringValue(node.TargetProvisionState)
+
+	// Only read traits back when they are managed, traits set by inspection
+	// or other tools are left alone
+	if !model.Traits.IsNull() {
+		traits, diags := types.SetValueFrom(ctx, types.StringType, append([]string{}, node.Traits...))
+		diagnostics.Append(diags...)
+		model.Traits = traits
+	}
 	model.VendorInterface = types.StringValue(node.VendorInterface)
 
 	model.Created = timeTypeOrNull(node.CreatedAt)
@@ -1055,6 +1349,90 @@ func timeTypeOrNull(v time.Time) timetypes.RFC3339 {
 	}
 }
 
+// applyTargetPowerState changes the power state of the node and refreshes
+// the model, including last_error when the change failed.
+func (r *NodeResource) applyTargetPowerState(
+	ctx context.Context,
+	model *NodeResourceModel,
+	target types.String,
+	diagnostics *diag.Diagnostics,
+) {
+	nodeUUID := model.ID.ValueString()
+
+	err := changePowerState(
+		ctx,
+		r.meta.Client,
+		nodeUUID,
+		nodes.TargetPowerState(target.ValueString()),
+		int(model.PowerStateTimeout.ValueInt64()),
+	)
+	if err != nil {
+		diagnostics.AddError(
+			"Error changing node power state",
+			fmt.Sprintf("Could not change power state of node %s: %s", nodeUUID, err),
+		)
+	}
+
+	model.TargetPowerState = target
+	r.readNodeData(ctx, model, diagnostics)
+}
+
+// updateNodeTraits adds and removes node traits one at a time, so that
+// traits managed outside of the plan are only touched when they differ.
+// Traits that were not managed before replace all the traits of the node,
+// as they are all read back from then on.
+func (r *NodeResource) updateNodeTraits(
+	ctx context.Context,
+	nodeID string,
+	plan *NodeResourceModel,
+	state *NodeResourceModel,
+	diagnostics *diag.Diagnostics,
+) {
+	var current, desired []string
+	if !state.Traits.IsNull() && !state.Traits.IsUnknown() {
+		diagnostics.Append(state.Traits.ElementsAs(ctx, &current, false)...)
+	}
+	if !plan.Traits.IsNull() && !plan.Traits.IsUnknown() {
+		diagnostics.Append(plan.Traits.ElementsAs(ctx, &desired, false)...)
+	}
+	if diagnostics.HasError() {
+		return
+	}
+
+	if state.Traits.IsNull() && !plan.Traits.IsNull() && !plan.Traits.IsUnknown() {
+		if err := setNodeTraits(ctx, r.meta.Client, nodeID, desired); err != nil {
+			diagnostics.AddAttributeError(
+				path.Root("traits"),
+				"Error setting node traits",
+				fmt.Sprintf("Could not set traits of node %s: %s", nodeID, err),
+			)
+		}
+		return
+	}
+
+	add, remove := nodeTraitsDiff(current, desired)
+	for _, trait := range remove {
+		if err := removeNodeTrait(ctx, r.meta.Client, nodeID, trait); err != nil {
+			diagnostics.AddAttributeError(
+				path.Root("traits"),
+				"Error removing node trait",
+				fmt.Sprintf("Could not remove trait %s from node %s: %s", trait, nodeID, err),
+			)
+			return
+		}
+	}
+	for _, trait := range add {
+		if err := addNodeTrait(ctx, r.meta.Client, nodeID, trait); err != nil {
+			diagnostics.AddAttributeError(
+				path.Root("traits"),
+				"Error adding node trait",
+				fmt.Sprintf("Could not add trait %s to node %s: %s", trait, nodeID, err),
+			)
+			return
+		}
+	}
+}
+
 // addDynamicUpdateOps handles changes for dynamic attributes.
 func (r *NodeResource) addDynamicUpdateOps(
 	ctx context.Context,
@@ -1173,13 +1551,89 @@ func (r *NodeResource) addDynamicUpdateOpsForField(
 	}
 }
 
-// addInterfaceUpdateOps handles changes for interface attributes.
+// interfaceUpdateStates are the provision states in which Ironic allows
+// changing the driver and the hardware interfaces of a node. Nodes in
+// maintenance can be changed in any state.
+var interfaceUpdateStates = []nodes.ProvisionState{
+	nodes.Enroll,
+	nodes.Inspecting,
+	nodes.InspectWait,
+	nodes.Manageable,
+	nodes.Available,
+}
+
+// resourceClassUpdateStates are the provision states in which Ironic allows
+// changing a resource class that is already set, maintenance or not.
+var resourceClassUpdateStates = []nodes.ProvisionState{
+	nodes.Enroll,
+	nodes.Inspecting,
+	nodes.InspectWait,
+	nodes.Manageable,
+}
+
+// interfaceUpdateAllowed reports whether Ironic accepts changes of the
+// driver and hardware interfaces of a node.
+func interfaceUpdateAllowed(provisionState string, maintenance bool) bool {
+	return maintenance || slices.Contains(interfaceUpdateStates, nodes.ProvisionState(provisionState))
+}
+
+// nodeInterfaceFields returns the hardware interface attributes of the model
+// by API field name.
+func nodeInterfaceFields(model *NodeResourceModel) map[string]types.String {
+	return map[string]types.String{
+		"bios_interface":       model.BIOSInterface,
+		"boot_interface":       model.BootInterface,
+		"console_interface":    model.ConsoleInterface,
+		"deploy_interface":     model.DeployInterface,
+		"firmware_interface":   model.FirmwareInterface,
+		"inspect_interface":    model.InspectInterface,
+		"management_interface": model.ManagementInterface,
+		"network_interface":    model.NetworkInterface,
+		"power_interface":      model.PowerInterface,
+		"raid_interface":       model.RAIDInterface,
+		"rescue_interface":     model.RescueInterface,
+		"storage_interface":    model.StorageInterface,
+		"vendor_interface":     model.VendorInterface,
+	}
+}
+
+// nodeStringFields returns the other writable string attributes of the
+// model by API field name.
+func nodeStringFields(model *NodeResourceModel) map[string]types.String {
+	return map[string]types.String{
+		"chassis_uuid":    model.Chassis,
+		"conductor_group": model.ConductorGroup,
+		"lessee":          model.Lessee,
+		"owner":           model.Owner,
+		"resource_class":  model.ResourceClass,
+	}
+}
+
+// changedNodeFields returns the names of the fields whose planned value is
+// known and differs from the state, in sorted order.
+func changedNodeFields(planFields, stateFields map[string]types.String) []string {
+	var changed []string
+	for field, planValue := range planFields {
+		if planValue.IsNull() || planValue.IsUnknown() || planValue.Equal(stateFields[field]) {
+			continue
+		}
+		changed = append(changed, field)
+	}
+	slices.Sort(changed)
+	return changed
+}
+
+// addInterfaceUpdateOps handles changes for hardware interface attributes.
+// Ironic resets an interface to the driver default when it is removed.
 func (r *NodeResource) addInterfaceUpdateOps(
 	updateOpts *nodes.UpdateOpts,
 	plan *NodeResourceModel,
 	state *NodeResourceModel,
 ) {
-	// TODO: Implement interface update logic
+	planFields := nodeInterfaceFields(plan)
+	for _, field := range changedNodeFields(planFields, nodeInterfaceFields(state)) {
+		*updateOpts = append(*updateOpts, nodeFieldUpdateOp(field, planFields[field]))
+	}
 }
 
 // addStringUpdateOps handles changes for string attributes.
@@ -1188,20 +1642,78 @@ func (r *NodeResource) addStringUpdateOps(
 	plan *NodeResourceModel,
 	state *NodeResourceModel,
 ) {
-	if !plan.BIOSInterface.Equal(state.BIOSInterface) {
-		*updateOpts = append(*updateOpts, nodes.UpdateOperation{
-			Op:    nodes.ReplaceOp,
-			Path:  "/bios_interface",
-			Value: plan.BIOSInterface.ValueString(),
-		})
+	planFields := nodeStringFields(plan)
+	for _, field := range changedNodeFields(planFields, nodeStringFields(state)) {
+		*updateOpts = append(*updateOpts, nodeFieldUpdateOp(field, planFields[field]))
 	}
-	if !plan.ResourceClass.Equal(state.ResourceClass) {
-		*updateOpts = append(*updateOpts, nodes.UpdateOperation{
-			Op:    nodes.ReplaceOp,
-			Path:  "/resource_class",
-			Value: plan.ResourceClass.ValueString(),
-		})
+}
+
+// nodeFieldUpdateOp returns the operation setting a node field, an empty
+// value removes it.
+func nodeFieldUpdateOp(field string, value types.String) nodes.UpdateOperation {
+	if value.ValueString() == "" {
+		return nodes.UpdateOperation{
+			Op:   nodes.RemoveOp,
+			Path: "/" + field,
+		}
+	}
+	return nodes.UpdateOperation{
+		Op:    nodes.ReplaceOp,
+		Path:  "/" + field,
+		Value: value.ValueString(),
+	}
+}
+
+// validateInterfaceUpdates reports changes of the driver, the hardware
+// interfaces or the resource class that Ironic would refuse in the current
+// provision state of the node.
+func validateInterfaceUpdates(plan, state *NodeResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	provisionState := state.ProvisionState.ValueString()
+
+	if !interfaceUpdateAllowed(provisionState, state.Maintenance.ValueBool()) {
+		changed := changedNodeFields(nodeInterfaceFields(plan), nodeInterfaceFields(state))
+		if !plan.Driver.Equal(state.Driver) {
+			changed = append(changed, "driver")
+		}
+		for _, field := range changed {
+			diags.AddAttributeError(
+				path.Root(field),
+				"Invalid Node State for Update",
+				fmt.Sprintf(
+					"%s can only be changed while the node is in maintenance or in one of the %s provision states, the node is %q.",
+					field, quotedStates(interfaceUpdateStates), provisionState,
+				),
+			)
+		}
+	}
+
+	// Ironic only restricts changing a resource class that is already set,
+	// and maintenance does not lift the restriction
+	if state.ResourceClass.ValueString() != "" &&
+		slices.Contains(changedNodeFields(nodeStringFields(plan), nodeStringFields(state)), "resource_class") &&
+		!slices.Contains(resourceClassUpdateStates, nodes.ProvisionState(provisionState)) {
+		diags.AddAttributeError(
+			path.Root("resource_class"),
+			"Invalid Node State for Update",
+			fmt.Sprintf(
+				"resource_class can only be changed while the node is in one of the %s provision states, the node is %q.",
+				quotedStates(resourceClassUpdateStates), provisionState,
+			),
+		)
 	}
+
+	return diags
+}
+
+// quotedStates returns the provision states quoted and separated by commas.
+func quotedStates(provisionStates []nodes.ProvisionState) string {
+	quoted := make([]string, len(provisionStates))
+	for i, provisionState := range provisionStates {
+		quoted[i] = fmt.Sprintf("%q", provisionState)
+	}
+	return strings.Join(quoted, ", ")
 }
 
 // addBooleanUpdateOps handles changes for boolean attributes.
@@ -1210,11 +1722,51 @@ func (r *NodeResource) addBooleanUpdateOps(
 	plan *NodeResourceModel,
 	state *NodeResourceModel,
 ) {
-	// TODO: Implement boolean update logic
+	if !plan.Automated.IsUnknown() && !plan.Automated.Equal(state.Automated) {
+		*updateOpts = append(*updateOpts, nodes.UpdateOperation{
+			Op:    nodes.ReplaceOp,
+			Path:  "/automated_clean",
+			Value: plan.Automated.ValueBool(),
+		})
+	}
+
+	if !plan.Protected.IsUnknown() && !plan.Protected.Equal(state.Protected) {
+		*updateOpts = append(*updateOpts, nodes.UpdateOperation{
+			Op:    nodes.ReplaceOp,
+			Path:  "/protected",
+			Value: plan.Protected.ValueBool(),
+		})
+	}
+
+	// Maintenance has its own API, see handleActionAttributes
+}
+
+// configuredMaintenance makes maintenance unknown when it is not set in the
+// config, so that handleActionAttributes leaves the node's maintenance mode
+// alone.
+func configuredMaintenance(ctx context.Context, config tfsdk.Config, maintenance *types.Bool) diag.Diagnostics {
+	var configMaintenance types.Bool
+	diags := config.GetAttribute(ctx, path.Root("maintenance"), &configMaintenance)
+	if configMaintenance.IsNull() {
+		*maintenance = types.BoolUnknown()
+	}
+	return diags
+}
+
+// hasNodeActions reports whether handleActionAttributes changes the
+// provision state of the node.
+func hasNodeActions(model *NodeResourceModel, nodeInfo *nodes.Node) bool {
+	inspect := model.Inspect.ValueBool() &&
+		(model.InspectionFinished.IsNull() || model.InspectionFinished.IsUnknown()) &&
+		nodeInfo.ProvisionState == string(nodes.Manageable)
+	return model.Clean.ValueBool() || inspect || model.Available.ValueBool() || model.Manage.ValueBool()
 }
 
 // handleActionAttributes handles the action attributes (clean, inspect, available, manage).
 // These attributes trigger state changes but are not persisted in the API.
+// Maintenance is handled here too, as Ironic refuses provision state changes
+// of nodes in maintenance: it is left before the actions and entered after
+// them. An unknown maintenance is not managed and left as it is.
 func (r *NodeResource) handleActionAttributes(
 	ctx context.Context,
 	model *NodeResourceModel,
@@ -1231,18 +1783,45 @@ func (r *NodeResource) handleActionAttributes(
 		return
 	}
 
+	manageMaintenance := !model.Maintenance.IsNull() && !model.Maintenance.IsUnknown()
+	wantMaintenance := model.Maintenance.ValueBool()
+	maintenanceReason := model.MaintenanceReason.ValueString()
+	model.Maintenance = types.BoolValue(nodeInfo.Maintenance)
+	model.MaintenanceReason = types.StringValue(nodeInfo.MaintenanceReason)
+
+	// Leave maintenance before any provision state change, it is entered
+	// again afterwards when wanted
+	inMaintenance := nodeInfo.Maintenance
+	if manageMaintenance && inMaintenance && (!wantMaintenance || hasNodeActions(model, nodeInfo)) {
+		err := nodes.UnsetMaintenance(ctx, r.meta.Client, nodeUUID).ExtractErr()
+		if err != nil {
+			diagnostics.AddError(
+				"Error unsetting maintenance",
+				fmt.Sprintf("Could not take node %s out of maintenance: %s", nodeUUID, err),
+			)
+			return
+		}
+		inMaintenance = false
+		model.Maintenance = types.BoolValue(false)
+		model.MaintenanceReason = types.StringValue("")
+	}
+
 	// Handle clean action
 	if !model.Clean.IsNull() && model.Clean.ValueBool() {
-		err := ChangeProvisionStateToTarget(
-			ctx,
-			r.meta.Client,
-			nodeUUID,
-			nodes.TargetClean,
-			nil,
-			nil,
-			nil,
-			nil, // serviceSteps
-		)
+		cleanSteps, diags := buildCleanSteps(ctx, model.CleanSteps)
+		diagnostics.Append(diags...)
+		if diagnostics.HasError() {
+			return
+		}
+
+		err := runProvisionWorkflow(&provisionWorkflow{
+			ctx:            ctx,
+			client:         r.meta.Client,
+			nodeID:         nodeUUID,
+			target:         nodes.TargetClean,
+			cleanSteps:     cleanSteps,
+			disableRamdisk: model.DisableRamdisk.ValueBool(),
+		})
 		if err != nil {
 			diagnostics.AddError(
 				"Error cleaning node",
@@ -1269,10 +1848,13 @@ func (r *NodeResource) handleActionAttributes(
 					nil, // serviceSteps
 				)
 				if err != nil {
-					diagnostics.AddError(
-						"Error inspecting node",
-						fmt.Sprintf("Could not inspect node %s: %s", nodeUUID, err),
-					)
+					detail := fmt.Sprintf("Could not inspect node %s: %s", nodeUUID, err)
+					if r.meta.usesInspector(nodeInfo) {
+						if inspectorErr := r.meta.introspectionError(ctx, nodeUUID); inspectorErr != "" {
+							detail += fmt.Sprintf(" (Ironic Inspector: %s)", inspectorErr)
+						}
+					}
+					diagnostics.AddError("Error inspecting node", detail)
 					return
 				}
 
@@ -1327,4 +1909,22 @@ func (r *NodeResource) handleActionAttributes(
 		// Reset the action attribute to null after triggering
 		model.Manage = types.BoolNull()
 	}
+
+	// Enter maintenance once the provision state changes are done, or update
+	// the reason of a node already in maintenance
+	if manageMaintenance && wantMaintenance &&
+		(!inMaintenance || maintenanceReason != nodeInfo.MaintenanceReason) {
+		err := nodes.SetMaintenance(ctx, r.meta.Client, nodeUUID, nodes.MaintenanceOpts{
+			Reason: maintenanceReason,
+		}).ExtractErr()
+		if err != nil {
+			diagnostics.AddError(
+				"Error setting maintenance",
+				fmt.Sprintf("Could not put node %s in maintenance: %s", nodeUUID, err),
+			)
+			return
+		}
+		model.Maintenance = types.BoolValue(true)
+		model.MaintenanceReason = types.StringValue(maintenanceReason)
+	}
 }
@@ -0,0 +1,252 @@
+package ironic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/noauth"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	th "github.com/metal3-community/terraform-provider-ironic/testhelper"
+)
+
+// fakeMaintenanceIronic is a fake Ironic that records the state changing
+// requests made to a single node, and refuses provision state changes while
+// the node is in maintenance like Ironic does.
+type fakeMaintenanceIronic struct {
+	mu             sync.Mutex
+	provisionState string
+	maintenance    bool
+	reason         string
+	requests       []string
+}
+
+func (f *fakeMaintenanceIronic) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Method != http.MethodGet {
+		f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+	}
+
+	var body map[string]any
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+	}
+
+	switch r.Method + " " + r.URL.Path {
+	case "GET /v1/nodes/node":
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"uuid": "node", "provision_state": %q, "maintenance": %t, "maintenance_reason": %q}`,
+			f.provisionState, f.maintenance, f.reason)
+	case "PUT /v1/nodes/node/maintenance":
+		f.maintenance = true
+		f.reason, _ = body["reason"].(string)
+		w.WriteHeader(http.StatusAccepted)
+	case "DELETE /v1/nodes/node/maintenance":
+		f.maintenance = false
+		f.reason = ""
+		w.WriteHeader(http.StatusAccepted)
+	case "PUT /v1/nodes/node/states/provision":
+		if f.maintenance {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if body["target"] == "provide" {
+			f.provisionState = "available"
+		}
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestHandleActionAttributesMaintenance(t *testing.T) {
+	defer func(interval time.Duration) { workflowPollInterval = interval }(workflowPollInterval)
+	workflowPollInterval = 10 * time.Millisecond
+
+	tests := []struct {
+		name        string
+		state       string
+		maintenance bool
+		reason      string
+		unmanaged   bool
+		wantModel   bool
+		wantReason  string
+		requests    []string
+	}{
+		{
+			name:        "leave before provide",
+			maintenance: true,
+			reason:      "faulty DIMM",
+			requests: []string{
+				"DELETE /v1/nodes/node/maintenance",
+				"PUT /v1/nodes/node/states/provision",
+			},
+		},
+		{
+			name:        "kept around provide",
+			maintenance: true,
+			reason:      "faulty DIMM",
+			wantModel:   true,
+			wantReason:  "faulty DIMM",
+			requests: []string{
+				"DELETE /v1/nodes/node/maintenance",
+				"PUT /v1/nodes/node/states/provision",
+				"PUT /v1/nodes/node/maintenance",
+			},
+		},
+		{
+			name:       "enter after provide",
+			wantModel:  true,
+			wantReason: "fenced",
+			requests: []string{
+				"PUT /v1/nodes/node/states/provision",
+				"PUT /v1/nodes/node/maintenance",
+			},
+		},
+		{
+			name:        "left alone when not set",
+			state:       "available",
+			maintenance: true,
+			reason:      "fenced by an operator",
+			unmanaged:   true,
+			wantModel:   true,
+			wantReason:  "fenced by an operator",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			provisionState := "manageable"
+			if test.state != "" {
+				provisionState = test.state
+			}
+			fake := &fakeMaintenanceIronic{
+				provisionState: provisionState,
+				maintenance:    test.maintenance,
+				reason:         test.reason,
+			}
+			server := httptest.NewServer(fake)
+			defer server.Close()
+
+			client, err := noauth.NewBareMetalNoAuth(noauth.EndpointOpts{
+				IronicEndpoint: server.URL + "/v1",
+			})
+			th.AssertNoError(t, err)
+
+			r := &NodeResource{meta: &Meta{Client: client}}
+			model := &NodeResourceModel{
+				ID:                types.StringValue("node"),
+				Available:         types.BoolValue(true),
+				Maintenance:       types.BoolValue(test.wantModel),
+				MaintenanceReason: types.StringValue(test.wantReason),
+			}
+			if test.unmanaged {
+				model.Maintenance = types.BoolUnknown()
+				model.MaintenanceReason = types.StringUnknown()
+			}
+
+			var diags diag.Diagnostics
+			r.handleActionAttributes(context.Background(), model, &diags)
+			if diags.HasError() {
+				t.Fatalf("unexpected errors: %v", diags)
+			}
+
+			if !reflect.DeepEqual(fake.requests, test.requests) {
+				t.Errorf("unexpected requests: %v", fake.requests)
+			}
+			if model.Maintenance.ValueBool() != test.wantModel ||
+				model.MaintenanceReason.ValueString() != test.wantReason {
+				t.Errorf("unexpected maintenance %v with reason %q",
+					model.Maintenance.ValueBool(), model.MaintenanceReason.ValueString())
+			}
+		})
+	}
+}
+
+func TestNodeUpdateOps(t *testing.T) {
+	state := &NodeResourceModel{
+		BootInterface:   types.StringValue("ipxe"),
+		DeployInterface: types.StringValue("direct"),
+		PowerInterface:  types.StringValue("ipmitool"),
+		Owner:           types.StringValue("infra"),
+		Lessee:          types.StringValue("tenant"),
+		ConductorGroup:  types.StringValue(""),
+	}
+	plan := &NodeResourceModel{
+		BootInterface:   types.StringValue("redfish-virtual-media"),
+		DeployInterface: types.StringValue("direct"),
+		PowerInterface:  types.StringUnknown(),
+		Owner:           types.StringValue("infra"),
+		Lessee:          types.StringValue(""),
+		ConductorGroup:  types.StringValue("rack-1"),
+		Chassis:         types.StringNull(),
+	}
+
+	var updateOpts nodes.UpdateOpts
+	r := &NodeResource{}
+	r.addInterfaceUpdateOps(&updateOpts, plan, state)
+	r.addStringUpdateOps(&updateOpts, plan, state)
+
+	expected := nodes.UpdateOpts{
+		nodes.UpdateOperation{Op: nodes.ReplaceOp, Path: "/boot_interface", Value: "redfish-virtual-media"},
+		nodes.UpdateOperation{Op: nodes.ReplaceOp, Path: "/conductor_group", Value: "rack-1"},
+		nodes.UpdateOperation{Op: nodes.RemoveOp, Path: "/lessee"},
+	}
+	if !reflect.DeepEqual(updateOpts, expected) {
+		t.Errorf("unexpected update operations: %+v", updateOpts)
+	}
+}
+
+func TestValidateInterfaceUpdates(t *testing.T) {
+	tests := []struct {
+		name           string
+		provisionState string
+		maintenance    bool
+		resourceClass  string
+		errors         []string
+	}{
+		{name: "manageable", provisionState: "manageable", resourceClass: "baremetal"},
+		{name: "active in maintenance", provisionState: "active", maintenance: true, resourceClass: "baremetal", errors: []string{"resource_class"}},
+		{name: "active in maintenance without resource class", provisionState: "active", maintenance: true},
+		{name: "available", provisionState: "available", resourceClass: "baremetal", errors: []string{"resource_class"}},
+		{name: "active", provisionState: "active", resourceClass: "baremetal", errors: []string{"boot_interface", "driver", "resource_class"}},
+		{name: "active without resource class", provisionState: "active", errors: []string{"boot_interface", "driver"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state := &NodeResourceModel{
+				ProvisionState: types.StringValue(test.provisionState),
+				Maintenance:    types.BoolValue(test.maintenance),
+				Driver:         types.StringValue("ipmi"),
+				BootInterface:  types.StringValue("ipxe"),
+				ResourceClass:  types.StringValue(test.resourceClass),
+				Owner:          types.StringValue("infra"),
+			}
+			plan := &NodeResourceModel{
+				Driver:        types.StringValue("redfish"),
+				BootInterface: types.StringValue("redfish-virtual-media"),
+				ResourceClass: types.StringValue("gpu"),
+				Owner:         types.StringValue("platform"),
+			}
+
+			var errors []string
+			for _, d := range validateInterfaceUpdates(plan, state).Errors() {
+				errors = append(errors, d.(diag.DiagnosticWithPath).Path().String())
+			}
+			if !reflect.DeepEqual(errors, test.errors) {
+				t.Errorf("expected errors for %v, got %v", test.errors, errors)
+			}
+		})
+	}
+}
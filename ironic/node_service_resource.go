@@ -0,0 +1,316 @@
+package ironic
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource               = &NodeServiceResource{}
+	_ resource.ResourceWithConfigure  = &NodeServiceResource{}
+	_ resource.ResourceWithModifyPlan = &NodeServiceResource{}
+)
+
+// nodeServiceMicroversions lists the microversions needed by servicing.
+var nodeServiceMicroversions = []microversionRequirement{
+	{Version: "1.87"},
+}
+
+// NodeServiceResource runs service steps on a deployed Ironic node.
+type NodeServiceResource struct {
+	meta *Meta
+}
+
+// NodeServiceResourceModel describes the resource data model.
+type NodeServiceResourceModel struct {
+	ID             types.String   `tfsdk:"id"`
+	NodeUUID       types.String   `tfsdk:"node_uuid"`
+	ServiceSteps   types.List     `tfsdk:"service_steps"`
+	DisableRamdisk types.Bool     `tfsdk:"disable_ramdisk"`
+	Triggers       types.Map      `tfsdk:"triggers"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewNodeServiceResource() resource.Resource {
+	return &NodeServiceResource{}
+}
+
+func (r *NodeServiceResource) Metadata(
+	ctx context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_node_service"
+}
+
+func (r *NodeServiceResource) Schema(
+	ctx context.Context,
+	req resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Runs service steps on an `active` Ironic node, for example to update firmware or BIOS settings without redeploying it. The steps run when the resource is created, and again whenever `service_steps`, `disable_ramdisk` or `triggers` change; Terraform waits for the node to be `active` again. Destroying the resource does not change the node.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The UUID of the node.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"node_uuid": schema.StringAttribute{
+				MarkdownDescription: "The UUID of the node.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"service_steps": schema.ListNestedAttribute{
+				MarkdownDescription: "The steps to run. Steps with a priority run first, highest priority first, and the others in list order.",
+				Required:            true,
+				NestedObject:        manualStepObject("service"),
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"disable_ramdisk": schema.BoolAttribute{
+				MarkdownDescription: "Run the service steps without booting the agent ramdisk, for steps that do not need it.",
+				Optional:            true,
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that run the service steps again when changed.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
+	}
+}
+
+func (r *NodeServiceResource) Configure(
+	ctx context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*Meta)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf(
+				"Expected *Clients, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+		return
+	}
+
+	r.meta = clients
+}
+
+// ModifyPlan reports when the Ironic API microversion is too old for
+// servicing.
+func (r *NodeServiceResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	// Nothing to check on destroy, or before the provider is configured.
+	if req.Plan.Raw.IsNull() || r.meta == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(
+		r.meta.validateMicroversions(ctx, "ironic_node_service", req.Config, nodeServiceMicroversions)...,
+	)
+}
+
+func (r *NodeServiceResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan NodeServiceResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := operationContext(ctx, createTimeout)
+	defer cancel()
+
+	plan.ID = plan.NodeUUID
+
+	r.serviceNode(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *NodeServiceResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state NodeServiceResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// There is nothing to refresh, only check the node still exists
+	_, err := nodes.Get(ctx, r.meta.Client, state.ID.ValueString()).Extract()
+	if err != nil {
+		if gophercloud.ResponseCodeIs(err, http.StatusNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error reading node",
+			fmt.Sprintf("Could not read node %s: %s", state.ID.ValueString(), err),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *NodeServiceResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var plan, state NodeServiceResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only run the steps again when something other than timeouts changed
+	if plan.ServiceSteps.Equal(state.ServiceSteps) &&
+		plan.DisableRamdisk.Equal(state.DisableRamdisk) &&
+		plan.Triggers.Equal(state.Triggers) {
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := operationContext(ctx, updateTimeout)
+	defer cancel()
+
+	r.serviceNode(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *NodeServiceResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	// Service steps cannot be undone, the node stays as it is
+	tflog.Info(ctx, "Leaving serviced node unchanged")
+}
+
+// serviceNode runs the service steps on the node and waits for it to be
+// active again.
+func (r *NodeServiceResource) serviceNode(
+	ctx context.Context,
+	model *NodeServiceResourceModel,
+	diagnostics *diag.Diagnostics,
+) {
+	nodeUUID := model.NodeUUID.ValueString()
+
+	serviceSteps, diags := buildCleanSteps(ctx, model.ServiceSteps)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return
+	}
+
+	node, err := nodes.Get(ctx, r.meta.Client, nodeUUID).Extract()
+	if err != nil {
+		diagnostics.AddError(
+			"Error reading node",
+			fmt.Sprintf("Could not read node %s: %s", nodeUUID, err),
+		)
+		return
+	}
+
+	if node.ProvisionState != string(nodes.Active) {
+		diagnostics.AddAttributeError(
+			path.Root("node_uuid"),
+			"Invalid Node State for Servicing",
+			fmt.Sprintf("Only active nodes can be serviced, but node %s is %s.", nodeUUID, node.ProvisionState),
+		)
+		return
+	}
+
+	tflog.Info(ctx, "Servicing node", map[string]any{
+		"node_id": nodeUUID,
+		"steps":   len(serviceSteps),
+	})
+
+	err = runProvisionWorkflow(&provisionWorkflow{
+		ctx:            ctx,
+		client:         r.meta.Client,
+		nodeID:         nodeUUID,
+		target:         nodes.TargetService,
+		serviceSteps:   serviceSteps,
+		disableRamdisk: model.DisableRamdisk.ValueBool(),
+	})
+	if err != nil {
+		diagnostics.AddError(
+			"Error servicing node",
+			fmt.Sprintf("Could not service node %s: %s", nodeUUID, err),
+		)
+	}
+}
@@ -0,0 +1,105 @@
+package ironic
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/noauth"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	th "github.com/metal3-community/terraform-provider-ironic/testhelper"
+)
+
+func TestServiceNode(t *testing.T) {
+	defer func(interval time.Duration) { workflowPollInterval = interval }(workflowPollInterval)
+	workflowPollInterval = 10 * time.Millisecond
+
+	tests := []struct {
+		name           string
+		provisionState string
+		lastError      string
+		requests       int
+		err            string
+	}{
+		{name: "success", provisionState: "active", requests: 1},
+		{name: "failure", provisionState: "active", lastError: "firmware image not found", requests: 1, err: "firmware image not found"},
+		{name: "not active", provisionState: "available", err: "Only active nodes can be serviced"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state, lastError := test.provisionState, ""
+			var requests []map[string]any
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("/v1/nodes/node/states/provision", func(w http.ResponseWriter, r *http.Request) {
+				var body map[string]any
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				requests = append(requests, body)
+				state = "servicing"
+				w.WriteHeader(http.StatusAccepted)
+			})
+			mux.HandleFunc("/v1/nodes/node", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"uuid":            "node",
+					"provision_state": state,
+					"last_error":      lastError,
+				})
+				// Servicing finishes after being seen once
+				if state == "servicing" {
+					state, lastError = "active", test.lastError
+					if test.lastError != "" {
+						state = "service failed"
+					}
+				}
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			client, err := noauth.NewBareMetalNoAuth(noauth.EndpointOpts{
+				IronicEndpoint: server.URL + "/v1",
+			})
+			th.AssertNoError(t, err)
+
+			stepAttrTypes := manualStepObject("service").Type().(types.ObjectType).AttrTypes
+			steps := types.ListValueMust(types.ObjectType{AttrTypes: stepAttrTypes}, []attr.Value{
+				types.ObjectValueMust(stepAttrTypes, map[string]attr.Value{
+					"interface": types.StringValue("firmware"),
+					"step":      types.StringValue("update"),
+					"args":      types.MapNull(types.StringType),
+					"priority":  types.Int64Null(),
+				}),
+			})
+
+			r := &NodeServiceResource{meta: &Meta{Client: client}}
+			model := &NodeServiceResourceModel{
+				NodeUUID:       types.StringValue("node"),
+				ServiceSteps:   steps,
+				DisableRamdisk: types.BoolValue(true),
+			}
+
+			var diags diag.Diagnostics
+			r.serviceNode(context.Background(), model, &diags)
+
+			if test.err == "" && diags.HasError() {
+				t.Fatalf("unexpected errors: %v", diags)
+			}
+			if test.err != "" && (!diags.HasError() || !strings.Contains(diags.Errors()[0].Detail(), test.err)) {
+				t.Errorf("expected error containing %q, got %v", test.err, diags)
+			}
+			if len(requests) != test.requests {
+				t.Fatalf("expected %d provision state requests, got %v", test.requests, requests)
+			}
+			if test.requests > 0 && (requests[0]["target"] != "service" || requests[0]["disable_ramdisk"] != true) {
+				t.Errorf("unexpected provision state request: %v", requests[0])
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package ironic
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"slices"
+
+	"github.com/gophercloud/gophercloud/v2"
+)
+
+// The node traits API needs microversion 1.37, gophercloud does not
+// implement it.
+
+// nodeTraitRegexp matches the names accepted by Ironic, both standard traits
+// and custom ones prefixed with CUSTOM_.
+var nodeTraitRegexp = regexp.MustCompile(`^[A-Z0-9_]+$`)
+
+// setNodeTraits replaces all the traits of a node.
+func setNodeTraits(
+	ctx context.Context,
+	client *gophercloud.ServiceClient,
+	nodeID string,
+	traits []string,
+) error {
+	if traits == nil {
+		traits = []string{}
+	}
+	body := map[string]any{"traits": traits}
+	_, err := client.Put(ctx, client.ServiceURL("nodes", nodeID, "traits"), body, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{http.StatusNoContent},
+	})
+	return err
+}
+
+// addNodeTrait adds a single trait to a node.
+func addNodeTrait(
+	ctx context.Context,
+	client *gophercloud.ServiceClient,
+	nodeID string,
+	trait string,
+) error {
+	_, err := client.Put(ctx, client.ServiceURL("nodes", nodeID, "traits", trait), nil, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{http.StatusNoContent},
+	})
+	return err
+}
+
+// removeNodeTrait removes a single trait from a node.
+func removeNodeTrait(
+	ctx context.Context,
+	client *gophercloud.ServiceClient,
+	nodeID string,
+	trait string,
+) error {
+	_, err := client.Delete(ctx, client.ServiceURL("nodes", nodeID, "traits", trait), nil)
+	return err
+}
+
+// nodeTraitsDiff returns the traits to add and remove to go from current to
+// desired, in sorted order.
+func nodeTraitsDiff(current, desired []string) (add, remove []string) {
+	for _, trait := range desired {
+		if !slices.Contains(current, trait) {
+			add = append(add, trait)
+		}
+	}
+	for _, trait := range current {
+		if !slices.Contains(desired, trait) {
+			remove = append(remove, trait)
+		}
+	}
+	slices.Sort(add)
+	slices.Sort(remove)
+	return add, remove
+}
@@ -0,0 +1,204 @@
+package ironic
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/noauth"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	th "github.com/metal3-community/terraform-provider-ironic/testhelper"
+)
+
+func TestNodeTraitsDiff(t *testing.T) {
+	add, remove := nodeTraitsDiff(
+		[]string{"CUSTOM_GPU", "HW_CPU_X86_AVX2", "CUSTOM_OLD"},
+		[]string{"HW_CPU_X86_AVX2", "CUSTOM_RAID", "CUSTOM_GPU", "CUSTOM_BIG"},
+	)
+	if !reflect.DeepEqual(add, []string{"CUSTOM_BIG", "CUSTOM_RAID"}) {
+		t.Errorf("unexpected traits to add: %v", add)
+	}
+	if !reflect.DeepEqual(remove, []string{"CUSTOM_OLD"}) {
+		t.Errorf("unexpected traits to remove: %v", remove)
+	}
+
+	add, remove = nodeTraitsDiff(nil, nil)
+	if add != nil || remove != nil {
+		t.Errorf("expected no changes, got %v and %v", add, remove)
+	}
+}
+
+// newNodeTraitsIronic serves the traits API of a node named node, with the
+// given traits.
+func newNodeTraitsIronic(t *testing.T, traits map[string]bool) *gophercloud.ServiceClient {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		trait, single := strings.CutPrefix(r.URL.Path, "/v1/nodes/node/traits/")
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/v1/nodes/node/traits":
+			var body struct {
+				Traits []string `json:"traits"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Traits == nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			clear(traits)
+			for _, trait := range body.Traits {
+				traits[trait] = true
+			}
+		case r.Method == http.MethodPut && single:
+			traits[trait] = true
+		case r.Method == http.MethodDelete && single:
+			if !traits[trait] {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			delete(traits, trait)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := noauth.NewBareMetalNoAuth(noauth.EndpointOpts{
+		IronicEndpoint: server.URL + "/v1",
+	})
+	th.AssertNoError(t, err)
+	return client
+}
+
+func TestNodeTraitsAPI(t *testing.T) {
+	traits := map[string]bool{}
+	client := newNodeTraitsIronic(t, traits)
+	ctx := context.Background()
+
+	th.AssertNoError(t, setNodeTraits(ctx, client, "node", []string{"CUSTOM_A", "CUSTOM_B"}))
+	th.AssertNoError(t, addNodeTrait(ctx, client, "node", "CUSTOM_C"))
+	th.AssertNoError(t, removeNodeTrait(ctx, client, "node", "CUSTOM_A"))
+	if !reflect.DeepEqual(traits, map[string]bool{"CUSTOM_B": true, "CUSTOM_C": true}) {
+		t.Errorf("unexpected traits: %v", traits)
+	}
+
+	th.AssertError(t, removeNodeTrait(ctx, client, "node", "CUSTOM_A"), "404")
+
+	// Clearing all traits must still send a list
+	th.AssertNoError(t, setNodeTraits(ctx, client, "node", nil))
+	if len(traits) != 0 {
+		t.Errorf("expected no traits, got %v", traits)
+	}
+}
+
+func TestUpdateNodeTraits(t *testing.T) {
+	set := func(traits ...string) types.Set {
+		values := make([]attr.Value, len(traits))
+		for i, trait := range traits {
+			values[i] = types.StringValue(trait)
+		}
+		return types.SetValueMust(types.StringType, values)
+	}
+
+	tests := []struct {
+		name     string
+		node     []string
+		state    types.Set
+		plan     types.Set
+		expected map[string]bool
+	}{
+		{
+			name:     "not managed before",
+			node:     []string{"CUSTOM_INSPECTED"},
+			state:    types.SetNull(types.StringType),
+			plan:     set("CUSTOM_GPU"),
+			expected: map[string]bool{"CUSTOM_GPU": true},
+		},
+		{
+			name:     "managed",
+			node:     []string{"CUSTOM_GPU", "CUSTOM_OLD"},
+			state:    set("CUSTOM_GPU", "CUSTOM_OLD"),
+			plan:     set("CUSTOM_GPU", "CUSTOM_RAID"),
+			expected: map[string]bool{"CUSTOM_GPU": true, "CUSTOM_RAID": true},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			traits := map[string]bool{}
+			for _, trait := range test.node {
+				traits[trait] = true
+			}
+			r := &NodeResource{meta: &Meta{Client: newNodeTraitsIronic(t, traits)}}
+
+			var diags diag.Diagnostics
+			r.updateNodeTraits(
+				context.Background(),
+				"node",
+				&NodeResourceModel{Traits: test.plan},
+				&NodeResourceModel{Traits: test.state},
+				&diags,
+			)
+			if diags.HasError() {
+				t.Fatalf("unexpected errors: %v", diags)
+			}
+			if !reflect.DeepEqual(traits, test.expected) {
+				t.Errorf("expected traits %v, got %v", test.expected, traits)
+			}
+		})
+	}
+}
+
+func TestReadNodeDataTraits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"uuid": "node", "automated_clean": true, "traits": ["CUSTOM_INSPECTED"]}`))
+	}))
+	defer server.Close()
+
+	client, err := noauth.NewBareMetalNoAuth(noauth.EndpointOpts{
+		IronicEndpoint: server.URL + "/v1",
+	})
+	th.AssertNoError(t, err)
+	r := &NodeResource{meta: &Meta{Client: client}}
+
+	tests := []struct {
+		name     string
+		traits   types.Set
+		expected types.Set
+	}{
+		{
+			name:     "not managed",
+			traits:   types.SetNull(types.StringType),
+			expected: types.SetNull(types.StringType),
+		},
+		{
+			name:     "managed",
+			traits:   types.SetValueMust(types.StringType, nil),
+			expected: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("CUSTOM_INSPECTED")}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			model := &NodeResourceModel{ID: types.StringValue("node"), Traits: test.traits}
+
+			var diags diag.Diagnostics
+			r.readNodeData(context.Background(), model, &diags)
+			if diags.HasError() {
+				t.Fatalf("unexpected errors: %v", diags)
+			}
+			if !model.Traits.Equal(test.expected) {
+				t.Errorf("expected traits %v, got %v", test.expected, model.Traits)
+			}
+		})
+	}
+}
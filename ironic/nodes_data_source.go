@@ -0,0 +1,377 @@
+package ironic
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+	"github.com/gophercloud/gophercloud/v2/pagination"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &NodesDataSource{}
+	_ datasource.DataSourceWithConfigure = &NodesDataSource{}
+)
+
+// nodesFilterMicroversions lists the microversions needed by the server-side
+// filters.
+var nodesFilterMicroversions = []microversionRequirement{
+	{Attribute: "provision_state", Version: "1.9"},
+	{Attribute: "driver", Version: "1.16"},
+	{Attribute: "resource_class", Version: "1.21"},
+	{Attribute: "fault", Version: "1.42"},
+	{Attribute: "conductor_group", Version: "1.46"},
+	{Attribute: "owner", Version: "1.50"},
+	{Attribute: "lessee", Version: "1.65"},
+	{Attribute: "shard", Version: "1.82"},
+}
+
+// nodeSummaryFields are the node fields returned by the data source, with
+// the microversion they appeared in. Fields the client microversion does not
+// know are not requested.
+var nodeSummaryFields = []microversionRequirement{
+	{Attribute: "uuid"},
+	{Attribute: "name"},
+	{Attribute: "instance_uuid"},
+	{Attribute: "provision_state"},
+	{Attribute: "power_state"},
+	{Attribute: "maintenance"},
+	{Attribute: "driver"},
+	{Attribute: "resource_class", Version: "1.21"},
+	{Attribute: "traits", Version: "1.37"},
+	{Attribute: "fault", Version: "1.42"},
+	{Attribute: "conductor_group", Version: "1.46"},
+	{Attribute: "owner", Version: "1.50"},
+	{Attribute: "lessee", Version: "1.65"},
+	{Attribute: "shard", Version: "1.82"},
+}
+
+// NodesDataSource lists the Ironic nodes matching a set of filters.
+type NodesDataSource struct {
+	meta *Meta
+}
+
+// nodesDataSourceModel describes the data source data model.
+type nodesDataSourceModel struct {
+	ID             types.String       `tfsdk:"id"`
+	ProvisionState types.String       `tfsdk:"provision_state"`
+	ResourceClass  types.String       `tfsdk:"resource_class"`
+	ConductorGroup types.String       `tfsdk:"conductor_group"`
+	Owner          types.String       `tfsdk:"owner"`
+	Lessee         types.String       `tfsdk:"lessee"`
+	Driver         types.String       `tfsdk:"driver"`
+	Fault          types.String       `tfsdk:"fault"`
+	Maintenance    types.Bool         `tfsdk:"maintenance"`
+	Associated     types.Bool         `tfsdk:"associated"`
+	Shard          types.String       `tfsdk:"shard"`
+	Traits         types.Set          `tfsdk:"traits"`
+	NamePrefix     types.String       `tfsdk:"name_prefix"`
+	Nodes          []nodeSummaryModel `tfsdk:"nodes"`
+}
+
+// nodeSummaryModel describes a listed node.
+type nodeSummaryModel struct {
+	UUID           types.String `tfsdk:"uuid"`
+	Name           types.String `tfsdk:"name"`
+	InstanceUUID   types.String `tfsdk:"instance_uuid"`
+	ProvisionState types.String `tfsdk:"provision_state"`
+	PowerState     types.String `tfsdk:"power_state"`
+	Maintenance    types.Bool   `tfsdk:"maintenance"`
+	Driver         types.String `tfsdk:"driver"`
+	ResourceClass  types.String `tfsdk:"resource_class"`
+	Traits         types.Set    `tfsdk:"traits"`
+	Fault          types.String `tfsdk:"fault"`
+	ConductorGroup types.String `tfsdk:"conductor_group"`
+	Owner          types.String `tfsdk:"owner"`
+	Lessee         types.String `tfsdk:"lessee"`
+	Shard          types.String `tfsdk:"shard"`
+}
+
+func NewNodesDataSource() datasource.DataSource {
+	return &NodesDataSource{}
+}
+
+func (d *NodesDataSource) Metadata(
+	ctx context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_nodes"
+}
+
+func (d *NodesDataSource) Schema(
+	ctx context.Context,
+	req datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	filter := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{
+			MarkdownDescription: description,
+			Optional:            true,
+		}
+	}
+	computed := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{
+			MarkdownDescription: description,
+			Computed:            true,
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the Ironic nodes matching the given filters. All filters are optional and combined, `traits` and `name_prefix` are applied by the provider, the others by Ironic.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Data source identifier.",
+				Computed:            true,
+			},
+			"provision_state": filter("Only list nodes in this provision state."),
+			"resource_class":  filter("Only list nodes with this resource class."),
+			"conductor_group": filter("Only list nodes in this conductor group."),
+			"owner":           filter("Only list nodes with this owner."),
+			"lessee":          filter("Only list nodes with this lessee."),
+			"driver":          filter("Only list nodes using this driver."),
+			"fault":           filter("Only list nodes with this fault."),
+			"shard":           filter("Only list nodes in this shard."),
+			"maintenance": schema.BoolAttribute{
+				MarkdownDescription: "Only list nodes in maintenance when true, or not in maintenance when false.",
+				Optional:            true,
+			},
+			"associated": schema.BoolAttribute{
+				MarkdownDescription: "Only list nodes with an instance when true, or without one when false.",
+				Optional:            true,
+			},
+			"traits": schema.SetAttribute{
+				MarkdownDescription: "Only list nodes having all of these traits.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"name_prefix": filter("Only list nodes whose name starts with this prefix."),
+			"nodes": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching nodes, in the order returned by Ironic.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"uuid":            computed("The UUID of the node."),
+						"name":            computed("The name of the node."),
+						"instance_uuid":   computed("The UUID of the instance deployed on the node."),
+						"provision_state": computed("The provision state of the node."),
+						"power_state":     computed("The power state of the node."),
+						"maintenance": schema.BoolAttribute{
+							MarkdownDescription: "Whether the node is in maintenance mode.",
+							Computed:            true,
+						},
+						"driver":         computed("The driver of the node."),
+						"resource_class": computed("The resource class of the node."),
+						"traits": schema.SetAttribute{
+							MarkdownDescription: "The traits of the node.",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"fault":           computed("The fault of the node."),
+						"conductor_group": computed("The conductor group of the node."),
+						"owner":           computed("The owner of the node."),
+						"lessee":          computed("The lessee of the node."),
+						"shard":           computed("The shard of the node."),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *NodesDataSource) Configure(
+	ctx context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*Meta)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf(
+				"Expected *Clients, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+		return
+	}
+
+	d.meta = clients
+}
+
+func (d *NodesDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var config nodesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(
+		d.meta.validateMicroversions(ctx, "ironic_nodes", req.Config, nodesFilterMicroversions)...,
+	)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := nodesListOpts{
+		ListOpts: nodes.ListOpts{
+			ProvisionState: nodes.ProvisionState(config.ProvisionState.ValueString()),
+			ResourceClass:  config.ResourceClass.ValueString(),
+			ConductorGroup: config.ConductorGroup.ValueString(),
+			Owner:          config.Owner.ValueString(),
+			Driver:         config.Driver.ValueString(),
+			Fault:          config.Fault.ValueString(),
+			Fields:         nodeSummaryFieldNames(d.meta.Client.Microversion),
+		},
+		Lessee:      config.Lessee.ValueString(),
+		Shard:       config.Shard.ValueString(),
+		Maintenance: config.Maintenance.ValueBoolPointer(),
+		Associated:  config.Associated.ValueBoolPointer(),
+	}
+
+	var traits []string
+	if !config.Traits.IsNull() {
+		resp.Diagnostics.Append(config.Traits.ElementsAs(ctx, &traits, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	found, err := listNodes(ctx, d.meta.Client, opts)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to List Nodes",
+			fmt.Sprintf("Unable to list nodes: %s", err),
+		)
+		return
+	}
+
+	config.Nodes = []nodeSummaryModel{}
+	for _, node := range found {
+		if !strings.HasPrefix(node.Name, config.NamePrefix.ValueString()) {
+			continue
+		}
+		if slices.ContainsFunc(traits, func(trait string) bool { return !slices.Contains(node.Traits, trait) }) {
+			continue
+		}
+
+		nodeTraits, diags := types.SetValueFrom(ctx, types.StringType, append([]string{}, node.Traits...))
+		resp.Diagnostics.Append(diags...)
+		config.Nodes = append(config.Nodes, nodeSummaryModel{
+			UUID:           types.StringValue(node.UUID),
+			Name:           types.StringValue(node.Name),
+			InstanceUUID:   types.StringValue(node.InstanceUUID),
+			ProvisionState: types.StringValue(node.ProvisionState),
+			PowerState:     types.StringValue(node.PowerState),
+			Maintenance:    types.BoolValue(node.Maintenance),
+			Driver:         types.StringValue(node.Driver),
+			ResourceClass:  types.StringValue(node.ResourceClass),
+			Traits:         nodeTraits,
+			Fault:          types.StringValue(node.Fault),
+			ConductorGroup: types.StringValue(node.ConductorGroup),
+			Owner:          types.StringValue(node.Owner),
+			Lessee:         types.StringValue(node.Lessee),
+			Shard:          types.StringValue(node.Shard),
+		})
+	}
+	tflog.Debug(ctx, "Listed nodes", map[string]any{
+		"listed":   len(found),
+		"matching": len(config.Nodes),
+	})
+
+	// The filters identify the data source
+	query, err := opts.ToNodeListQuery()
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to List Nodes", err.Error())
+		return
+	}
+	config.ID = types.StringValue("nodes" + query)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// nodeSummaryFieldNames returns the summary fields known at microversion.
+func nodeSummaryFieldNames(microversion string) []string {
+	var fields []string
+	for _, field := range nodeSummaryFields {
+		if field.Version == "" || microversion == "" || microversionAtLeast(microversion, field.Version) {
+			fields = append(fields, field.Attribute)
+		}
+	}
+	return fields
+}
+
+// nodesListOpts adds the node list filters gophercloud does not know about,
+// or cannot set to false.
+type nodesListOpts struct {
+	nodes.ListOpts
+	// Lessee requires microversion 1.65.
+	Lessee string
+	// Shard requires microversion 1.82.
+	Shard       string
+	Maintenance *bool
+	Associated  *bool
+}
+
+// ToNodeListQuery formats the options into a query string.
+func (opts nodesListOpts) ToNodeListQuery() (string, error) {
+	q, err := gophercloud.BuildQueryString(opts.ListOpts)
+	if err != nil {
+		return "", err
+	}
+
+	query := q.Query()
+	if opts.Lessee != "" {
+		query.Set("lessee", opts.Lessee)
+	}
+	if opts.Shard != "" {
+		query.Set("shard", opts.Shard)
+	}
+	if opts.Maintenance != nil {
+		query.Set("maintenance", strconv.FormatBool(*opts.Maintenance))
+	}
+	if opts.Associated != nil {
+		query.Set("associated", strconv.FormatBool(*opts.Associated))
+	}
+	q.RawQuery = query.Encode()
+	return q.String(), nil
+}
+
+// ToNodeListDetailQuery formats the options into a query string.
+func (opts nodesListOpts) ToNodeListDetailQuery() (string, error) {
+	return opts.ToNodeListQuery()
+}
+
+// listNodes lists all the nodes matching opts.
+func listNodes(
+	ctx context.Context,
+	client *gophercloud.ServiceClient,
+	opts nodesListOpts,
+) ([]nodes.Node, error) {
+	return listAll(ctx,
+		func(limit int, marker string) pagination.Pager {
+			opts.Limit, opts.Marker = limit, marker
+			return nodes.List(client, opts)
+		},
+		nodes.ExtractNodes,
+		func(node nodes.Node) string { return node.UUID },
+	)
+}
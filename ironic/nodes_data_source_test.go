@@ -0,0 +1,165 @@
+package ironic
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/noauth"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	th "github.com/metal3-community/terraform-provider-ironic/testhelper"
+)
+
+func TestNodesDataSourceRead(t *testing.T) {
+	all := []map[string]any{
+		{"uuid": "0c0e1c38-5d4e-4a2b-8a43-7c1f2d1c0a01", "name": "worker-0", "traits": []string{"CUSTOM_GPU", "CUSTOM_RAID"}},
+		{"uuid": "0c0e1c38-5d4e-4a2b-8a43-7c1f2d1c0a02", "name": "worker-1", "traits": []string{"CUSTOM_RAID"}},
+		{"uuid": "0c0e1c38-5d4e-4a2b-8a43-7c1f2d1c0a03", "name": "master-0", "traits": []string{"CUSTOM_GPU"}},
+	}
+
+	var queries []url.Values
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/nodes", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		queries = append(queries, query)
+
+		start := 0
+		for i, node := range all {
+			if node["uuid"] == query.Get("marker") {
+				start = i + 1
+			}
+		}
+		end := min(start+listPageSize, len(all))
+		page := map[string]any{"nodes": all[start:end]}
+		// Ironic links the next page when the page is full
+		if end-start == listPageSize {
+			page["next"] = r.URL.String()
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(page)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := noauth.NewBareMetalNoAuth(noauth.EndpointOpts{
+		IronicEndpoint: server.URL + "/v1",
+	})
+	th.AssertNoError(t, err)
+	client.Microversion = "1.80"
+
+	pageSize := listPageSize
+	listPageSize = 2
+	defer func() { listPageSize = pageSize }()
+
+	ctx := context.Background()
+	d := &NodesDataSource{meta: &Meta{Client: client}}
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	if diags := schemaResp.Schema.ValidateImplementation(ctx); diags.HasError() {
+		t.Fatalf("invalid schema: %v", diags)
+	}
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	read := func(config map[string]tftypes.Value) datasource.ReadResponse {
+		values := map[string]tftypes.Value{}
+		for name, attributeType := range objectType.AttributeTypes {
+			values[name] = tftypes.NewValue(attributeType, nil)
+		}
+		for name, value := range config {
+			values[name] = value
+		}
+
+		req := datasource.ReadRequest{
+			Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+		}
+		resp := datasource.ReadResponse{
+			State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+		}
+		d.Read(ctx, req, &resp)
+		return resp
+	}
+
+	t.Run("filters", func(t *testing.T) {
+		queries = nil
+		resp := read(map[string]tftypes.Value{
+			"provision_state": tftypes.NewValue(tftypes.String, "available"),
+			"conductor_group": tftypes.NewValue(tftypes.String, "rack-1"),
+			"lessee":          tftypes.NewValue(tftypes.String, "project"),
+			"maintenance":     tftypes.NewValue(tftypes.Bool, false),
+			"name_prefix":     tftypes.NewValue(tftypes.String, "worker-"),
+			"traits": tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{
+				tftypes.NewValue(tftypes.String, "CUSTOM_RAID"),
+			}),
+		})
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+		}
+
+		if len(queries) != 2 {
+			t.Fatalf("expected 2 pages to be requested, got %d", len(queries))
+		}
+		for key, expected := range map[string]string{
+			"provision_state": "available",
+			"conductor_group": "rack-1",
+			"lessee":          "project",
+			"maintenance":     "false",
+			"limit":           "2",
+		} {
+			if value := queries[0].Get(key); value != expected {
+				t.Errorf("expected query %s to be %q, got %q", key, expected, value)
+			}
+		}
+		if marker := queries[1].Get("marker"); marker != "0c0e1c38-5d4e-4a2b-8a43-7c1f2d1c0a02" {
+			t.Errorf("unexpected marker %q", marker)
+		}
+		if fields := queries[0].Get("fields"); !strings.Contains(fields, "lessee") ||
+			strings.Contains(fields, "shard") {
+			t.Errorf("unexpected fields %q", fields)
+		}
+
+		var state nodesDataSourceModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+		}
+		if len(state.Nodes) != 2 ||
+			state.Nodes[0].Name.ValueString() != "worker-0" ||
+			state.Nodes[1].Name.ValueString() != "worker-1" {
+			t.Errorf("unexpected nodes: %v", state.Nodes)
+		}
+	})
+
+	t.Run("trait filter", func(t *testing.T) {
+		resp := read(map[string]tftypes.Value{
+			"traits": tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{
+				tftypes.NewValue(tftypes.String, "CUSTOM_GPU"),
+				tftypes.NewValue(tftypes.String, "CUSTOM_RAID"),
+			}),
+		})
+		var state nodesDataSourceModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+		}
+		if len(state.Nodes) != 1 || state.Nodes[0].Name.ValueString() != "worker-0" {
+			t.Errorf("unexpected nodes: %v", state.Nodes)
+		}
+	})
+
+	t.Run("unsupported filter", func(t *testing.T) {
+		resp := read(map[string]tftypes.Value{
+			"shard": tftypes.NewValue(tftypes.String, "shard-1"),
+		})
+		if !resp.Diagnostics.HasError() ||
+			!strings.Contains(resp.Diagnostics.Errors()[0].Detail(), "requires Ironic API microversion 1.82") {
+			t.Errorf("expected a microversion error, got %v", resp.Diagnostics)
+		}
+	})
+}
@@ -0,0 +1,55 @@
+package ironic
+
+import (
+	"context"
+
+	"github.com/gophercloud/gophercloud/v2/pagination"
+)
+
+// listPageSize is the number of items requested at once.
+var listPageSize = 200
+
+// listAll lists all the items of a collection. Ironic returns its next page
+// link as "next" rather than in the links gophercloud follows, so the pages
+// are requested one by one with the UUID of the last item as marker, for as
+// long as Ironic links a next page. The API may cap the limit to its
+// max_limit, so the size of a page does not tell whether it is the last.
+func listAll[T any](
+	ctx context.Context,
+	list func(limit int, marker string) pagination.Pager,
+	extract func(pagination.Page) ([]T, error),
+	uuid func(T) string,
+) ([]T, error) {
+	var all []T
+
+	marker := ""
+	for {
+		var page []T
+		var next bool
+		err := list(listPageSize, marker).EachPage(ctx, func(ctx context.Context, p pagination.Page) (bool, error) {
+			var err error
+			page, err = extract(p)
+			next = hasNextPage(p)
+			return false, err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, page...)
+		if !next || len(page) == 0 {
+			return all, nil
+		}
+		marker = uuid(page[len(page)-1])
+	}
+}
+
+// hasNextPage reports whether Ironic links a page after p.
+func hasNextPage(p pagination.Page) bool {
+	body, ok := p.GetBody().(map[string]any)
+	if !ok {
+		return false
+	}
+	next, _ := body["next"].(string)
+	return next != ""
+}
@@ -0,0 +1,67 @@
+package ironic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/noauth"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/ports"
+	"github.com/gophercloud/gophercloud/v2/pagination"
+	th "github.com/metal3-community/terraform-provider-ironic/testhelper"
+)
+
+func TestListAllMaxLimit(t *testing.T) {
+	// Ironic caps the requested limit to its [api]max_limit
+	const maxLimit = 2
+
+	var all []map[string]any
+	for i := range 5 {
+		all = append(all, map[string]any{"uuid": fmt.Sprintf("port-%d", i)})
+	}
+
+	requests := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/ports", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		start := 0
+		for i, port := range all {
+			if port["uuid"] == r.URL.Query().Get("marker") {
+				start = i + 1
+			}
+		}
+		end := min(start+maxLimit, len(all))
+		page := map[string]any{"ports": all[start:end]}
+		if end-start == maxLimit {
+			page["next"] = r.URL.String()
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(page)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := noauth.NewBareMetalNoAuth(noauth.EndpointOpts{
+		IronicEndpoint: server.URL + "/v1",
+	})
+	th.AssertNoError(t, err)
+
+	found, err := listAll(context.Background(),
+		func(limit int, marker string) pagination.Pager {
+			return ports.List(client, ports.ListOpts{Limit: limit, Marker: marker})
+		},
+		ports.ExtractPorts,
+		func(port ports.Port) string { return port.UUID },
+	)
+	th.AssertNoError(t, err)
+
+	if len(found) != len(all) {
+		t.Errorf("expected %d ports, got %d", len(all), len(found))
+	}
+	if requests != 3 {
+		t.Errorf("expected 3 pages to be requested, got %d", requests)
+	}
+}
@@ -0,0 +1,31 @@
+package ironic
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+)
+
+// patchResource applies JSON patch operations to the Ironic resource at the
+// given path, for the resources gophercloud cannot update.
+func patchResource(
+	ctx context.Context,
+	client *gophercloud.ServiceClient,
+	opts nodes.UpdateOpts,
+	resourcePath ...string,
+) error {
+	body := make([]map[string]any, 0, len(opts))
+	for _, patch := range opts {
+		operation, err := patch.ToNodeUpdateMap()
+		if err != nil {
+			return err
+		}
+		body = append(body, operation)
+	}
+	_, err := client.Patch(ctx, client.ServiceURL(resourcePath...), body, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{http.StatusOK},
+	})
+	return err
+}
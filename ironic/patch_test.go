@@ -0,0 +1,51 @@
+package ironic
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/noauth"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+	th "github.com/metal3-community/terraform-provider-ironic/testhelper"
+)
+
+func TestPatchResource(t *testing.T) {
+	var patch []map[string]any
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/portgroups/pg", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("unexpected method %s", r.Method)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&patch)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"uuid": "pg"}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := noauth.NewBareMetalNoAuth(noauth.EndpointOpts{
+		IronicEndpoint: server.URL + "/v1",
+	})
+	th.AssertNoError(t, err)
+
+	err = patchResource(context.Background(), client, nodes.UpdateOpts{
+		nodes.UpdateOperation{Op: nodes.ReplaceOp, Path: "/name", Value: "bond1"},
+		nodes.UpdateOperation{Op: nodes.AddOp, Path: "/properties/xmit_hash_policy", Value: "layer3+4"},
+		nodes.UpdateOperation{Op: nodes.RemoveOp, Path: "/physical_network"},
+	}, "portgroups", "pg")
+	th.AssertNoError(t, err)
+
+	expected := []map[string]any{
+		{"op": "replace", "path": "/name", "value": "bond1"},
+		{"op": "add", "path": "/properties/xmit_hash_policy", "value": "layer3+4"},
+		{"op": "remove", "path": "/physical_network"},
+	}
+	if !reflect.DeepEqual(patch, expected) {
+		t.Errorf("unexpected patch: %v", patch)
+	}
+}
@@ -0,0 +1,167 @@
+package ironic
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/ports"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                     = &PortDataSource{}
+	_ datasource.DataSourceWithConfigure        = &PortDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &PortDataSource{}
+)
+
+// PortDataSource looks up an existing Ironic port.
+type PortDataSource struct {
+	meta *Meta
+}
+
+func NewPortDataSource() datasource.DataSource {
+	return &PortDataSource{}
+}
+
+func (d *PortDataSource) Metadata(
+	ctx context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_port"
+}
+
+// Schema returns the attributes of the ironic_port resource as computed
+// attributes, the port is looked up by its UUID or MAC address.
+func (d *PortDataSource) Schema(
+	ctx context.Context,
+	req datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up an existing Ironic port by UUID or MAC address, and returns the same attributes as the `ironic_port` resource.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The UUID of the port, or the UUID to look the port up by.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"node_uuid": schema.StringAttribute{
+				MarkdownDescription: "The UUID of the node this port belongs to.",
+				Computed:            true,
+			},
+			"address": schema.StringAttribute{
+				MarkdownDescription: "The MAC address of the port, or the MAC address to look the port up by.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"port_group_uuid": schema.StringAttribute{
+				MarkdownDescription: "The UUID of the port group this port belongs to.",
+				Computed:            true,
+			},
+			"local_link_connection": schema.DynamicAttribute{
+				MarkdownDescription: "The local link connection information for the port.",
+				Computed:            true,
+			},
+			"pxe_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether PXE is enabled for this port.",
+				Computed:            true,
+			},
+			"physical_network": schema.StringAttribute{
+				MarkdownDescription: "The physical network name for the port.",
+				Computed:            true,
+			},
+			"extra": schema.DynamicAttribute{
+				MarkdownDescription: "Extra metadata for the port.",
+				Computed:            true,
+			},
+			"is_smart_nic": schema.BoolAttribute{
+				MarkdownDescription: "Whether this is a Smart NIC port.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *PortDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("address")),
+	}
+}
+
+func (d *PortDataSource) Configure(
+	ctx context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*Meta)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf(
+				"Expected *Clients, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+		return
+	}
+
+	d.meta = clients
+}
+
+func (d *PortDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var id, address types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("id"), &id)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("address"), &address)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	portUUID := id.ValueString()
+	if id.IsNull() {
+		page, err := ports.List(d.meta.Client, ports.ListOpts{Address: address.ValueString()}).AllPages(ctx)
+		var found []ports.Port
+		if err == nil {
+			found, err = ports.ExtractPorts(page)
+		}
+		if err == nil && len(found) == 0 {
+			err = fmt.Errorf("no port has MAC address %s", address.ValueString())
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Find Port",
+				fmt.Sprintf("Unable to find port: %s", err),
+			)
+			return
+		}
+		portUUID = found[0].UUID
+	}
+	tflog.Debug(ctx, "Found port", map[string]any{"uuid": portUUID})
+
+	// Read the port the same way the ironic_port resource does
+	model := PortResourceModel{ID: types.StringValue(portUUID)}
+	(&NewPortResource{meta: d.meta}).readPortData(ctx, &model, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep the address as configured, Ironic stores it in lower case
+	if !address.IsNull() {
+		model.Address = address
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
@@ -0,0 +1,160 @@
+package ironic
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/noauth"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	th "github.com/metal3-community/terraform-provider-ironic/testhelper"
+)
+
+// readDataSource reads d with the given configuration, the other attributes
+// being null.
+func readDataSource(
+	t *testing.T,
+	d datasource.DataSource,
+	config map[string]tftypes.Value,
+) datasource.ReadResponse {
+	ctx := context.Background()
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	if diags := schemaResp.Schema.ValidateImplementation(ctx); diags.HasError() {
+		t.Fatalf("invalid schema: %v", diags)
+	}
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+	for name, value := range config {
+		values[name] = value
+	}
+
+	req := datasource.ReadRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+	}
+	resp := datasource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+	}
+	d.Read(ctx, req, &resp)
+	return resp
+}
+
+func TestPortDataSourcesRead(t *testing.T) {
+	all := []map[string]any{
+		{
+			"uuid":             "c4b5e3a2-6c1e-4b59-9d0e-3f3b8c1f2a10",
+			"address":          "52:54:00:cf:2d:31",
+			"node_uuid":        "1be26c0b-03f2-4d2e-ae87-c02d7f33c123",
+			"pxe_enabled":      true,
+			"physical_network": "provisioning",
+			"local_link_connection": map[string]any{
+				"switch_id": "0a:1b:2c:3d:4e:5f",
+				"port_id":   "Ethernet1/1",
+			},
+		},
+		{
+			"uuid":             "c4b5e3a2-6c1e-4b59-9d0e-3f3b8c1f2a11",
+			"address":          "52:54:00:cf:2d:32",
+			"node_uuid":        "1be26c0b-03f2-4d2e-ae87-c02d7f33c123",
+			"physical_network": "tenant",
+		},
+	}
+
+	mux := http.NewServeMux()
+	list := func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		found := []any{}
+		for _, port := range all {
+			if address := query.Get("address"); address != "" && address != port["address"] {
+				continue
+			}
+			if nodeUUID := query.Get("node_uuid"); nodeUUID != "" && nodeUUID != port["node_uuid"] {
+				continue
+			}
+			found = append(found, port)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"ports": found})
+	}
+	mux.HandleFunc("/v1/ports", list)
+	mux.HandleFunc("/v1/ports/detail", list)
+	mux.HandleFunc("/v1/ports/", func(w http.ResponseWriter, r *http.Request) {
+		for _, port := range all {
+			if r.URL.Path == "/v1/ports/"+port["uuid"].(string) {
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(port)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := noauth.NewBareMetalNoAuth(noauth.EndpointOpts{
+		IronicEndpoint: server.URL + "/v1",
+	})
+	th.AssertNoError(t, err)
+	meta := &Meta{Client: client}
+	ctx := context.Background()
+
+	t.Run("port by address", func(t *testing.T) {
+		resp := readDataSource(t, &PortDataSource{meta: meta}, map[string]tftypes.Value{
+			"address": tftypes.NewValue(tftypes.String, "52:54:00:cf:2d:31"),
+		})
+		var state PortResourceModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+		}
+		if state.ID.ValueString() != "c4b5e3a2-6c1e-4b59-9d0e-3f3b8c1f2a10" || !state.PXEEnabled.ValueBool() {
+			t.Errorf("unexpected port: %v", state)
+		}
+		if !strings.Contains(state.LocalLinkConnection.String(), "Ethernet1/1") {
+			t.Errorf("unexpected local link connection: %v", state.LocalLinkConnection)
+		}
+	})
+
+	t.Run("port by unknown address", func(t *testing.T) {
+		resp := readDataSource(t, &PortDataSource{meta: meta}, map[string]tftypes.Value{
+			"address": tftypes.NewValue(tftypes.String, "52:54:00:cf:2d:33"),
+		})
+		if !resp.Diagnostics.HasError() ||
+			!strings.Contains(resp.Diagnostics.Errors()[0].Detail(), "no port has MAC address") {
+			t.Errorf("expected a lookup error, got %v", resp.Diagnostics)
+		}
+	})
+
+	t.Run("ports by physical network", func(t *testing.T) {
+		resp := readDataSource(t, &PortsDataSource{meta: meta}, map[string]tftypes.Value{
+			"node_uuid":        tftypes.NewValue(tftypes.String, "1be26c0b-03f2-4d2e-ae87-c02d7f33c123"),
+			"physical_network": tftypes.NewValue(tftypes.String, "provisioning"),
+		})
+		var state portsDataSourceModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+		}
+		if len(state.Ports) != 1 {
+			t.Fatalf("expected 1 port, got %v", state.Ports)
+		}
+		port := state.Ports[0]
+		if port.Address.ValueString() != "52:54:00:cf:2d:31" || !port.PXEEnabled.ValueBool() {
+			t.Errorf("unexpected port: %v", port)
+		}
+		if switchPort := port.LocalLinkConnection.Elements()["port_id"]; switchPort == nil ||
+			switchPort.String() != `"Ethernet1/1"` {
+			t.Errorf("unexpected local link connection: %v", port.LocalLinkConnection)
+		}
+	})
+}
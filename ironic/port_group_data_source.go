@@ -0,0 +1,188 @@
+package ironic
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/portgroups"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                     = &PortGroupDataSource{}
+	_ datasource.DataSourceWithConfigure        = &PortGroupDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &PortGroupDataSource{}
+)
+
+// PortGroupDataSource looks up an existing Ironic port group.
+type PortGroupDataSource struct {
+	meta *Meta
+}
+
+func NewPortGroupDataSource() datasource.DataSource {
+	return &PortGroupDataSource{}
+}
+
+func (d *PortGroupDataSource) Metadata(
+	ctx context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_port_group"
+}
+
+// Schema returns the attributes of the ironic_port_group resource as
+// computed attributes, the port group is looked up by its UUID, name or MAC
+// address.
+func (d *PortGroupDataSource) Schema(
+	ctx context.Context,
+	req datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up an existing Ironic port group by UUID, name or MAC address, and returns the same attributes as the `ironic_port_group` resource.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The UUID of the port group.",
+				Computed:            true,
+			},
+			"uuid": schema.StringAttribute{
+				MarkdownDescription: "The UUID of the port group, or the UUID to look the port group up by.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"node_uuid": schema.StringAttribute{
+				MarkdownDescription: "The UUID of the node this port group belongs to.",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the port group, or the name to look the port group up by.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"address": schema.StringAttribute{
+				MarkdownDescription: "The MAC address of the port group, or the MAC address to look the port group up by.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"mode": schema.StringAttribute{
+				MarkdownDescription: "The bonding mode of the port group.",
+				Computed:            true,
+			},
+			"standalone_ports_supported": schema.BoolAttribute{
+				MarkdownDescription: "Whether the ports of the port group can also be used as standalone ports.",
+				Computed:            true,
+			},
+			"properties": schema.DynamicAttribute{
+				MarkdownDescription: "Bonding properties of the port group, such as `miimon` or `xmit_hash_policy`.",
+				Computed:            true,
+			},
+			"physical_network": schema.StringAttribute{
+				MarkdownDescription: "The physical network the ports of the port group are connected to.",
+				Computed:            true,
+			},
+			"extra": schema.DynamicAttribute{
+				MarkdownDescription: "Extra metadata for the port group.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *PortGroupDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("uuid"),
+			path.MatchRoot("name"),
+			path.MatchRoot("address"),
+		),
+	}
+}
+
+func (d *PortGroupDataSource) Configure(
+	ctx context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*Meta)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf(
+				"Expected *Clients, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+		return
+	}
+
+	d.meta = clients
+}
+
+func (d *PortGroupDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var uuid, name, address types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("uuid"), &uuid)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("name"), &name)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("address"), &address)...)
+	resp.Diagnostics.Append(
+		d.meta.validateMicroversions(ctx, "ironic_port_group", req.Config, portGroupMicroversions)...,
+	)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Ironic gets port groups by UUID or name
+	ident := uuid.ValueString()
+	if !name.IsNull() {
+		ident = name.ValueString()
+	}
+	if !address.IsNull() {
+		page, err := portgroups.List(
+			d.meta.Client,
+			portgroups.ListOpts{Address: address.ValueString()},
+		).AllPages(ctx)
+		var found []portgroups.PortGroup
+		if err == nil {
+			found, err = portgroups.ExtractPortGroups(page)
+		}
+		if err == nil && len(found) == 0 {
+			err = fmt.Errorf("no port group has MAC address %s", address.ValueString())
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Find Port Group",
+				fmt.Sprintf("Unable to find port group: %s", err),
+			)
+			return
+		}
+		ident = found[0].UUID
+	}
+
+	// Read the port group the same way the ironic_port_group resource does
+	model := PortGroupResourceModel{ID: types.StringValue(ident)}
+	(&PortGroupResource{meta: d.meta}).readPortgroupData(ctx, &model, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Found port group", map[string]any{"uuid": model.UUID.ValueString()})
+
+	// Keep the address as configured, Ironic stores it in lower case
+	if !address.IsNull() {
+		model.Address = address
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
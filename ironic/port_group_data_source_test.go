@@ -0,0 +1,94 @@
+package ironic
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/noauth"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	th "github.com/metal3-community/terraform-provider-ironic/testhelper"
+)
+
+func TestPortGroupDataSourcesRead(t *testing.T) {
+	portGroup := map[string]any{
+		"uuid":             "6eb02b44-18a3-4659-8c0b-8d2802581ae4",
+		"name":             "bond0",
+		"address":          "52:54:00:cf:2d:31",
+		"node_uuid":        "1be26c0b-03f2-4d2e-ae87-c02d7f33c123",
+		"mode":             "802.3ad",
+		"properties":       map[string]any{"miimon": 100, "xmit_hash_policy": "layer3+4"},
+		"physical_network": "provisioning",
+	}
+
+	var listQuery string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/portgroups", func(w http.ResponseWriter, r *http.Request) {
+		listQuery = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"portgroups": []any{portGroup}})
+	})
+	mux.HandleFunc("/v1/portgroups/", func(w http.ResponseWriter, r *http.Request) {
+		ident := r.URL.Path[len("/v1/portgroups/"):]
+		if ident != portGroup["uuid"] && ident != portGroup["name"] {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(portGroup)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := noauth.NewBareMetalNoAuth(noauth.EndpointOpts{
+		IronicEndpoint: server.URL + "/v1",
+	})
+	th.AssertNoError(t, err)
+	meta := &Meta{Client: client}
+	ctx := context.Background()
+
+	for name, config := range map[string]map[string]tftypes.Value{
+		"uuid":    {"uuid": tftypes.NewValue(tftypes.String, "6eb02b44-18a3-4659-8c0b-8d2802581ae4")},
+		"name":    {"name": tftypes.NewValue(tftypes.String, "bond0")},
+		"address": {"address": tftypes.NewValue(tftypes.String, "52:54:00:cf:2d:31")},
+	} {
+		t.Run("port group by "+name, func(t *testing.T) {
+			resp := readDataSource(t, &PortGroupDataSource{meta: meta}, config)
+			var state PortGroupResourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+			}
+			if state.UUID.ValueString() != "6eb02b44-18a3-4659-8c0b-8d2802581ae4" ||
+				state.Mode.ValueString() != "802.3ad" ||
+				state.PhysicalNetwork.ValueString() != "provisioning" {
+				t.Errorf("unexpected port group: %v", state)
+			}
+		})
+	}
+
+	t.Run("port groups", func(t *testing.T) {
+		resp := readDataSource(t, &PortGroupsDataSource{meta: meta}, map[string]tftypes.Value{
+			"node_uuid": tftypes.NewValue(tftypes.String, "1be26c0b-03f2-4d2e-ae87-c02d7f33c123"),
+		})
+		var state portGroupsDataSourceModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+		}
+		if listQuery != "detail=true&limit=200&node=1be26c0b-03f2-4d2e-ae87-c02d7f33c123" {
+			t.Errorf("unexpected query %q", listQuery)
+		}
+		if len(state.PortGroups) != 1 {
+			t.Fatalf("expected 1 port group, got %v", state.PortGroups)
+		}
+		if miimon := state.PortGroups[0].Properties.Elements()["miimon"]; miimon == nil || miimon.String() != `"100"` {
+			t.Errorf("unexpected properties: %v", state.PortGroups[0].Properties)
+		}
+		if state.PortGroups[0].PhysicalNetwork.ValueString() != "provisioning" {
+			t.Errorf("unexpected physical network: %v", state.PortGroups[0].PhysicalNetwork)
+		}
+	})
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
 	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/portgroups"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/dynamicplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
@@ -22,8 +24,18 @@ var (
 	_ resource.Resource                = &PortGroupResource{}
 	_ resource.ResourceWithConfigure   = &PortGroupResource{}
 	_ resource.ResourceWithImportState = &PortGroupResource{}
+	_ resource.ResourceWithModifyPlan  = &PortGroupResource{}
 )
 
+// portGroupMicroversions lists the microversions needed by port groups, the
+// API itself first appeared in 1.23.
+var portGroupMicroversions = []microversionRequirement{
+	{Version: "1.23"},
+	{Attribute: "mode", Version: "1.26"},
+	{Attribute: "properties", Version: "1.26"},
+	{Attribute: "physical_network", Version: "1.101"},
+}
+
 // PortGroupResource defines the resource implementation.
 type PortGroupResource struct {
 	meta *Meta
@@ -31,13 +43,16 @@ type PortGroupResource struct {
 
 // PortGroupResourceModel describes the resource data model.
 type PortGroupResourceModel struct {
-	ID       types.String  `tfsdk:"id"`
-	UUID     types.String  `tfsdk:"uuid"`
-	NodeUUID types.String  `tfsdk:"node_uuid"`
-	Name     types.String  `tfsdk:"name"`
-	Address  types.String  `tfsdk:"address"`
-	Mode     types.String  `tfsdk:"mode"`
-	Extra    types.Dynamic `tfsdk:"extra"`
+	ID                       types.String  `tfsdk:"id"`
+	UUID                     types.String  `tfsdk:"uuid"`
+	NodeUUID                 types.String  `tfsdk:"node_uuid"`
+	Name                     types.String  `tfsdk:"name"`
+	Address                  types.String  `tfsdk:"address"`
+	Mode                     types.String  `tfsdk:"mode"`
+	StandalonePortsSupported types.Bool    `tfsdk:"standalone_ports_supported"`
+	Properties               types.Dynamic `tfsdk:"properties"`
+	PhysicalNetwork          types.String  `tfsdk:"physical_network"`
+	Extra                    types.Dynamic `tfsdk:"extra"`
 }
 
 func NewPortGroupResource() resource.Resource {
@@ -91,7 +106,6 @@ func (r *PortGroupResource) Schema(
 				Computed:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
-					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"address": schema.StringAttribute{
@@ -100,7 +114,6 @@ func (r *PortGroupResource) Schema(
 				Computed:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
-					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"mode": schema.StringAttribute{
@@ -110,7 +123,30 @@ func (r *PortGroupResource) Schema(
 				Default:             stringdefault.StaticString("active-backup"),
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
-					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"standalone_ports_supported": schema.BoolAttribute{
+				MarkdownDescription: "Whether the ports of the port group can also be used as standalone ports. Ironic defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"properties": schema.DynamicAttribute{
+				MarkdownDescription: "Bonding properties of the port group, such as `miimon` or `xmit_hash_policy`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Dynamic{
+					dynamicplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"physical_network": schema.StringAttribute{
+				MarkdownDescription: "The physical network the ports of the port group are connected to.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
 			"extra": schema.DynamicAttribute{
@@ -149,6 +185,23 @@ func (r *PortGroupResource) Configure(
 	r.meta = clients
 }
 
+// ModifyPlan reports attributes that need a newer Ironic API microversion
+// than the provider is using.
+func (r *PortGroupResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	// Nothing to check on destroy, or before the provider is configured.
+	if req.Plan.Raw.IsNull() || r.meta == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(
+		r.meta.validateMicroversions(ctx, "ironic_port_group", req.Config, portGroupMicroversions)...,
+	)
+}
+
 func (r *PortGroupResource) Create(
 	ctx context.Context,
 	req resource.CreateRequest,
@@ -164,7 +217,7 @@ func (r *PortGroupResource) Create(
 	}
 
 	// Prepare create options
-	createOpts := portgroups.CreateOpts{}
+	createOpts := portGroupCreateOpts{}
 
 	// Set optional fields
 	if !plan.UUID.IsNull() && !plan.UUID.IsUnknown() {
@@ -187,6 +240,27 @@ func (r *PortGroupResource) Create(
 		createOpts.Mode = plan.Mode.ValueString()
 	}
 
+	if !plan.StandalonePortsSupported.IsNull() && !plan.StandalonePortsSupported.IsUnknown() {
+		createOpts.StandalonePortsSupported = plan.StandalonePortsSupported.ValueBoolPointer()
+	}
+
+	if !plan.PhysicalNetwork.IsNull() && !plan.PhysicalNetwork.IsUnknown() {
+		createOpts.PhysicalNetwork = plan.PhysicalNetwork.ValueString()
+	}
+
+	// Handle bonding properties
+	if !plan.Properties.IsNull() && !plan.Properties.IsUnknown() {
+		if properties, err := util.DynamicToMap(ctx, plan.Properties); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("properties"),
+				"Error Converting Properties",
+				fmt.Sprintf("Could not convert properties to map: %s", err),
+			)
+		} else {
+			createOpts.Properties = properties
+		}
+	}
+
 	// Handle extra data
 	if !plan.Extra.IsNull() && !plan.Extra.IsUnknown() {
 		if extra, err := util.DynamicToMap(ctx, plan.Extra); err != nil {
@@ -209,6 +283,10 @@ func (r *PortGroupResource) Create(
 		}
 	}
 
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Create the portgroup
 	portgroup, err := portgroups.Create(ctx, r.meta.Client, createOpts).Extract()
 	if err != nil {
@@ -263,12 +341,100 @@ func (r *PortGroupResource) Update(
 	req resource.UpdateRequest,
 	resp *resource.UpdateResponse,
 ) {
-	// Portgroups don't support updates in the original implementation
-	// All attributes are ForceNew, so this should not be called
-	resp.Diagnostics.AddError(
-		"Update not supported",
-		"Portgroup resources do not support updates. All changes require replacement.",
-	)
+	var plan PortGroupResourceModel
+	var state PortGroupResourceModel
+
+	// Get plan and current state
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Check for changes and build update operations
+	updateOpts := nodes.UpdateOpts{}
+
+	stringFields := []struct {
+		path        string
+		plan, state types.String
+	}{
+		{"/name", plan.Name, state.Name},
+		{"/address", plan.Address, state.Address},
+		{"/mode", plan.Mode, state.Mode},
+		{"/physical_network", plan.PhysicalNetwork, state.PhysicalNetwork},
+	}
+	for _, field := range stringFields {
+		if field.plan.Equal(field.state) || field.plan.IsUnknown() {
+			continue
+		}
+		if field.plan.IsNull() || field.plan.ValueString() == "" {
+			updateOpts = append(updateOpts, nodes.UpdateOperation{
+				Op:   nodes.RemoveOp,
+				Path: field.path,
+			})
+		} else {
+			updateOpts = append(updateOpts, nodes.UpdateOperation{
+				Op:    nodes.ReplaceOp,
+				Path:  field.path,
+				Value: field.plan.ValueString(),
+			})
+		}
+	}
+
+	if !plan.StandalonePortsSupported.Equal(state.StandalonePortsSupported) {
+		if !plan.StandalonePortsSupported.IsNull() && !plan.StandalonePortsSupported.IsUnknown() {
+			updateOpts = append(updateOpts, nodes.UpdateOperation{
+				Op:    nodes.ReplaceOp,
+				Path:  "/standalone_ports_supported",
+				Value: plan.StandalonePortsSupported.ValueBool(),
+			})
+		}
+	}
+
+	if !plan.Properties.IsUnknown() {
+		util.AddDynamicUpdateOpsForField(
+			ctx, &updateOpts, &resp.Diagnostics, plan.Properties, state.Properties, "properties",
+		)
+	}
+
+	if !plan.Extra.IsUnknown() {
+		util.AddDynamicUpdateOpsForField(
+			ctx, &updateOpts, &resp.Diagnostics, plan.Extra, state.Extra, "extra",
+		)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Perform the update if there are changes
+	if len(updateOpts) > 0 {
+		err := patchResource(ctx, r.meta.Client, updateOpts, "portgroups", state.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating portgroup",
+				fmt.Sprintf("Could not update portgroup %s: %s", state.ID.ValueString(), err),
+			)
+			return
+		}
+	}
+
+	// Read the updated portgroup
+	plan.ID = state.ID
+	r.readPortgroupData(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set updated state
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
 }
 
 func (r *PortGroupResource) Delete(
@@ -324,7 +490,15 @@ func (r *PortGroupResource) readPortgroupData(
 	model *PortGroupResourceModel,
 	diagnostics *diag.Diagnostics,
 ) {
-	portgroup, err := portgroups.Get(ctx, r.meta.Client, model.ID.ValueString()).Extract()
+	// physical_network is not part of the gophercloud portgroup
+	var physicalNetwork struct {
+		PhysicalNetwork string `json:"physical_network"`
+	}
+	result := portgroups.Get(ctx, r.meta.Client, model.ID.ValueString())
+	portgroup, err := result.Extract()
+	if err == nil {
+		err = result.ExtractInto(&physicalNetwork)
+	}
 	if err != nil {
 		diagnostics.AddError(
 			"Error reading portgroup",
@@ -340,6 +514,19 @@ func (r *PortGroupResource) readPortgroupData(
 	model.Name = types.StringValue(portgroup.Name)
 	model.Address = types.StringValue(portgroup.Address)
 	model.Mode = types.StringValue(portgroup.Mode)
+	model.StandalonePortsSupported = types.BoolValue(portgroup.StandalonePortsSupported)
+	model.PhysicalNetwork = types.StringValue(physicalNetwork.PhysicalNetwork)
+
+	// Handle bonding properties
+	properties, err := util.MapToDynamic(ctx, portgroup.Properties)
+	if err != nil {
+		diagnostics.AddError(
+			"Error converting properties",
+			fmt.Sprintf("Could not convert properties to dynamic: %s", err),
+		)
+		return
+	}
+	model.Properties = properties
 
 	// Handle extra data
 	if portgroup.Extra != nil {
@@ -356,3 +543,28 @@ func (r *PortGroupResource) readPortgroupData(
 		model.Extra = types.DynamicNull()
 	}
 }
+
+// portGroupCreateOpts adds the port group fields gophercloud does not know
+// about, or cannot set to false.
+type portGroupCreateOpts struct {
+	portgroups.CreateOpts
+	// StandalonePortsSupported is only sent when set, Ironic defaults to true.
+	StandalonePortsSupported *bool
+	// PhysicalNetwork requires microversion 1.101.
+	PhysicalNetwork string
+}
+
+// ToPortGroupCreateMap assembles the request body.
+func (opts portGroupCreateOpts) ToPortGroupCreateMap() (map[string]any, error) {
+	body, err := opts.CreateOpts.ToPortGroupCreateMap()
+	if err != nil {
+		return nil, err
+	}
+	if opts.StandalonePortsSupported != nil {
+		body["standalone_ports_supported"] = *opts.StandalonePortsSupported
+	}
+	if opts.PhysicalNetwork != "" {
+		body["physical_network"] = opts.PhysicalNetwork
+	}
+	return body, nil
+}
@@ -0,0 +1,36 @@
+package ironic
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/portgroups"
+	th "github.com/metal3-community/terraform-provider-ironic/testhelper"
+)
+
+func TestPortGroupCreateOpts(t *testing.T) {
+	standalone := false
+	opts := portGroupCreateOpts{
+		CreateOpts: portgroups.CreateOpts{
+			NodeUUID:   "node",
+			Mode:       "802.3ad",
+			Properties: map[string]any{"miimon": "100"},
+		},
+		StandalonePortsSupported: &standalone,
+		PhysicalNetwork:          "provisioning",
+	}
+
+	body, err := opts.ToPortGroupCreateMap()
+	th.AssertNoError(t, err)
+
+	expected := map[string]any{
+		"node_uuid":                  "node",
+		"mode":                       "802.3ad",
+		"properties":                 map[string]any{"miimon": "100"},
+		"standalone_ports_supported": false,
+		"physical_network":           "provisioning",
+	}
+	if !reflect.DeepEqual(body, expected) {
+		t.Errorf("unexpected request body: %v", body)
+	}
+}
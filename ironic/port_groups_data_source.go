@@ -0,0 +1,234 @@
+package ironic
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/portgroups"
+	"github.com/gophercloud/gophercloud/v2/pagination"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &PortGroupsDataSource{}
+	_ datasource.DataSourceWithConfigure = &PortGroupsDataSource{}
+)
+
+// PortGroupsDataSource lists the Ironic port groups matching a set of
+// filters.
+type PortGroupsDataSource struct {
+	meta *Meta
+}
+
+// portGroupsDataSourceModel describes the data source data model.
+type portGroupsDataSourceModel struct {
+	ID         types.String            `tfsdk:"id"`
+	NodeUUID   types.String            `tfsdk:"node_uuid"`
+	Address    types.String            `tfsdk:"address"`
+	PortGroups []portGroupSummaryModel `tfsdk:"port_groups"`
+}
+
+// portGroupSummaryModel describes a listed port group.
+type portGroupSummaryModel struct {
+	UUID                     types.String `tfsdk:"uuid"`
+	Name                     types.String `tfsdk:"name"`
+	NodeUUID                 types.String `tfsdk:"node_uuid"`
+	Address                  types.String `tfsdk:"address"`
+	Mode                     types.String `tfsdk:"mode"`
+	StandalonePortsSupported types.Bool   `tfsdk:"standalone_ports_supported"`
+	Properties               types.Map    `tfsdk:"properties"`
+	PhysicalNetwork          types.String `tfsdk:"physical_network"`
+}
+
+// listedPortGroup adds the port group fields gophercloud does not know about.
+type listedPortGroup struct {
+	portgroups.PortGroup
+	PhysicalNetwork string
+}
+
+// extractListedPortGroups extracts the port groups of a page with their
+// physical network.
+func extractListedPortGroups(page pagination.Page) ([]listedPortGroup, error) {
+	found, err := portgroups.ExtractPortGroups(page)
+	if err != nil {
+		return nil, err
+	}
+	var physicalNetworks []struct {
+		PhysicalNetwork string `json:"physical_network"`
+	}
+	if err := portgroups.ExtractPortGroupsInto(page, &physicalNetworks); err != nil {
+		return nil, err
+	}
+
+	listed := make([]listedPortGroup, len(found))
+	for i, portGroup := range found {
+		listed[i] = listedPortGroup{
+			PortGroup:       portGroup,
+			PhysicalNetwork: physicalNetworks[i].PhysicalNetwork,
+		}
+	}
+	return listed, nil
+}
+
+func NewPortGroupsDataSource() datasource.DataSource {
+	return &PortGroupsDataSource{}
+}
+
+func (d *PortGroupsDataSource) Metadata(
+	ctx context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_port_groups"
+}
+
+func (d *PortGroupsDataSource) Schema(
+	ctx context.Context,
+	req datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	computed := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{
+			MarkdownDescription: description,
+			Computed:            true,
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the Ironic port groups matching the given filters. All filters are optional and combined.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Data source identifier.",
+				Computed:            true,
+			},
+			"node_uuid": schema.StringAttribute{
+				MarkdownDescription: "Only list the port groups of this node.",
+				Optional:            true,
+			},
+			"address": schema.StringAttribute{
+				MarkdownDescription: "Only list the port group with this MAC address.",
+				Optional:            true,
+			},
+			"port_groups": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching port groups, in the order returned by Ironic.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"uuid":      computed("The UUID of the port group."),
+						"name":      computed("The name of the port group."),
+						"node_uuid": computed("The UUID of the node this port group belongs to."),
+						"address":   computed("The MAC address of the port group."),
+						"mode":      computed("The bonding mode of the port group."),
+						"standalone_ports_supported": schema.BoolAttribute{
+							MarkdownDescription: "Whether the ports of the port group can also be used as standalone ports.",
+							Computed:            true,
+						},
+						"properties": schema.MapAttribute{
+							MarkdownDescription: "Bonding properties of the port group, such as `miimon` or `xmit_hash_policy`.",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"physical_network": computed("The physical network the ports of the port group are connected to."),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *PortGroupsDataSource) Configure(
+	ctx context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*Meta)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf(
+				"Expected *Clients, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+		return
+	}
+
+	d.meta = clients
+}
+
+func (d *PortGroupsDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var config portGroupsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(
+		d.meta.validateMicroversions(ctx, "ironic_port_groups", req.Config, portGroupMicroversions[:1])...,
+	)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := portgroups.ListOpts{
+		Node:    config.NodeUUID.ValueString(),
+		Address: config.Address.ValueString(),
+		Detail:  true,
+	}
+	found, err := listAll(ctx,
+		func(limit int, marker string) pagination.Pager {
+			opts.Limit, opts.Marker = limit, marker
+			return portgroups.List(d.meta.Client, opts)
+		},
+		extractListedPortGroups,
+		func(portGroup listedPortGroup) string { return portGroup.UUID },
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to List Port Groups",
+			fmt.Sprintf("Unable to list port groups: %s", err),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Listed port groups", map[string]any{"listed": len(found)})
+
+	config.PortGroups = make([]portGroupSummaryModel, 0, len(found))
+	for _, portGroup := range found {
+		properties, diags := stringMapValue(portGroup.Properties)
+		resp.Diagnostics.Append(diags...)
+		config.PortGroups = append(config.PortGroups, portGroupSummaryModel{
+			UUID:                     types.StringValue(portGroup.UUID),
+			Name:                     types.StringValue(portGroup.Name),
+			NodeUUID:                 types.StringValue(portGroup.NodeUUID),
+			Address:                  types.StringValue(portGroup.Address),
+			Mode:                     types.StringValue(portGroup.Mode),
+			StandalonePortsSupported: types.BoolValue(portGroup.StandalonePortsSupported),
+			Properties:               properties,
+			PhysicalNetwork:          types.StringValue(portGroup.PhysicalNetwork),
+		})
+	}
+
+	// The filters identify the data source
+	filters := url.Values{}
+	for name, value := range map[string]types.String{
+		"node_uuid": config.NodeUUID,
+		"address":   config.Address,
+	} {
+		if !value.IsNull() {
+			filters.Set(name, value.ValueString())
+		}
+	}
+	config.ID = types.StringValue("port_groups?" + filters.Encode())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
@@ -22,8 +22,19 @@ var (
 	_ resource.Resource                = &NewPortResource{}
 	_ resource.ResourceWithConfigure   = &NewPortResource{}
 	_ resource.ResourceWithImportState = &NewPortResource{}
+	_ resource.ResourceWithModifyPlan  = &NewPortResource{}
 )
 
+// portMicroversions lists the port attributes that need a newer microversion
+// than the base Ironic API.
+var portMicroversions = []microversionRequirement{
+	{Attribute: "local_link_connection", Version: "1.19"},
+	{Attribute: "pxe_enabled", Version: "1.19"},
+	{Attribute: "port_group_uuid", Version: "1.24"},
+	{Attribute: "physical_network", Version: "1.34"},
+	{Attribute: "is_smart_nic", Version: "1.53"},
+}
+
 // NewPortResource defines the resource implementation.
 type NewPortResource struct {
 	meta *Meta
@@ -157,6 +168,23 @@ func (r *NewPortResource) Configure(
 	r.meta = clients
 }
 
+// ModifyPlan reports attributes that need a newer Ironic API microversion
+// than the provider is using.
+func (r *NewPortResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	// Nothing to check on destroy, or before the provider is configured.
+	if req.Plan.Raw.IsNull() || r.meta == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(
+		r.meta.validateMicroversions(ctx, "ironic_port", req.Config, portMicroversions)...,
+	)
+}
+
 func (r *NewPortResource) Create(
 	ctx context.Context,
 	req resource.CreateRequest,
@@ -0,0 +1,249 @@
+package ironic
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/ports"
+	"github.com/gophercloud/gophercloud/v2/pagination"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &PortsDataSource{}
+	_ datasource.DataSourceWithConfigure = &PortsDataSource{}
+)
+
+// portsFilterMicroversions lists the microversions needed by the filters.
+var portsFilterMicroversions = []microversionRequirement{
+	{Attribute: "port_group_uuid", Version: "1.24"},
+	{Attribute: "physical_network", Version: "1.34"},
+}
+
+// PortsDataSource lists the Ironic ports matching a set of filters.
+type PortsDataSource struct {
+	meta *Meta
+}
+
+// portsDataSourceModel describes the data source data model.
+type portsDataSourceModel struct {
+	ID              types.String       `tfsdk:"id"`
+	NodeUUID        types.String       `tfsdk:"node_uuid"`
+	PortGroupUUID   types.String       `tfsdk:"port_group_uuid"`
+	Address         types.String       `tfsdk:"address"`
+	PhysicalNetwork types.String       `tfsdk:"physical_network"`
+	Ports           []portSummaryModel `tfsdk:"ports"`
+}
+
+// portSummaryModel describes a listed port.
+type portSummaryModel struct {
+	UUID                types.String `tfsdk:"uuid"`
+	NodeUUID            types.String `tfsdk:"node_uuid"`
+	Address             types.String `tfsdk:"address"`
+	PortGroupUUID       types.String `tfsdk:"port_group_uuid"`
+	LocalLinkConnection types.Map    `tfsdk:"local_link_connection"`
+	PXEEnabled          types.Bool   `tfsdk:"pxe_enabled"`
+	PhysicalNetwork     types.String `tfsdk:"physical_network"`
+	IsSmartNIC          types.Bool   `tfsdk:"is_smart_nic"`
+}
+
+func NewPortsDataSource() datasource.DataSource {
+	return &PortsDataSource{}
+}
+
+func (d *PortsDataSource) Metadata(
+	ctx context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_ports"
+}
+
+func (d *PortsDataSource) Schema(
+	ctx context.Context,
+	req datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	filter := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{
+			MarkdownDescription: description,
+			Optional:            true,
+		}
+	}
+	computed := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{
+			MarkdownDescription: description,
+			Computed:            true,
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the Ironic ports matching the given filters, for example to configure the switches they are connected to. All filters are optional and combined.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Data source identifier.",
+				Computed:            true,
+			},
+			"node_uuid":        filter("Only list the ports of this node."),
+			"port_group_uuid":  filter("Only list the ports of this port group."),
+			"address":          filter("Only list the port with this MAC address."),
+			"physical_network": filter("Only list the ports connected to this physical network."),
+			"ports": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching ports, in the order returned by Ironic.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"uuid":            computed("The UUID of the port."),
+						"node_uuid":       computed("The UUID of the node this port belongs to."),
+						"address":         computed("The MAC address of the port."),
+						"port_group_uuid": computed("The UUID of the port group this port belongs to."),
+						"local_link_connection": schema.MapAttribute{
+							MarkdownDescription: "The switch the port is connected to, such as `switch_id`, `port_id` and `switch_info`.",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"pxe_enabled": schema.BoolAttribute{
+							MarkdownDescription: "Whether PXE is enabled for this port.",
+							Computed:            true,
+						},
+						"physical_network": computed("The physical network name for the port."),
+						"is_smart_nic": schema.BoolAttribute{
+							MarkdownDescription: "Whether this is a Smart NIC port.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *PortsDataSource) Configure(
+	ctx context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*Meta)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf(
+				"Expected *Clients, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+		return
+	}
+
+	d.meta = clients
+}
+
+func (d *PortsDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var config portsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(
+		d.meta.validateMicroversions(ctx, "ironic_ports", req.Config, portsFilterMicroversions)...,
+	)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Ironic cannot filter ports by physical network
+	opts := ports.ListOpts{
+		NodeUUID:  config.NodeUUID.ValueString(),
+		PortGroup: config.PortGroupUUID.ValueString(),
+		Address:   config.Address.ValueString(),
+	}
+	found, err := listAll(ctx,
+		func(limit int, marker string) pagination.Pager {
+			opts.Limit, opts.Marker = limit, marker
+			return ports.ListDetail(d.meta.Client, opts)
+		},
+		ports.ExtractPorts,
+		func(port ports.Port) string { return port.UUID },
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to List Ports",
+			fmt.Sprintf("Unable to list ports: %s", err),
+		)
+		return
+	}
+
+	config.Ports = []portSummaryModel{}
+	for _, port := range found {
+		if !config.PhysicalNetwork.IsNull() && port.PhysicalNetwork != config.PhysicalNetwork.ValueString() {
+			continue
+		}
+
+		localLinkConnection, diags := stringMapValue(port.LocalLinkConnection)
+		resp.Diagnostics.Append(diags...)
+		config.Ports = append(config.Ports, portSummaryModel{
+			UUID:                types.StringValue(port.UUID),
+			NodeUUID:            types.StringValue(port.NodeUUID),
+			Address:             types.StringValue(port.Address),
+			PortGroupUUID:       types.StringValue(port.PortGroupUUID),
+			LocalLinkConnection: localLinkConnection,
+			PXEEnabled:          types.BoolValue(port.PXEEnabled),
+			PhysicalNetwork:     types.StringValue(port.PhysicalNetwork),
+			IsSmartNIC:          types.BoolValue(port.IsSmartNIC),
+		})
+	}
+	tflog.Debug(ctx, "Listed ports", map[string]any{
+		"listed":   len(found),
+		"matching": len(config.Ports),
+	})
+
+	// The filters identify the data source
+	filters := url.Values{}
+	for name, value := range map[string]types.String{
+		"node_uuid":        config.NodeUUID,
+		"port_group_uuid":  config.PortGroupUUID,
+		"address":          config.Address,
+		"physical_network": config.PhysicalNetwork,
+	} {
+		if !value.IsNull() {
+			filters.Set(name, value.ValueString())
+		}
+	}
+	config.ID = types.StringValue("ports?" + filters.Encode())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// stringMapValue converts a JSON object with scalar values to a map of
+// strings, as collections of dynamic values cannot be nested in a list.
+func stringMapValue(value map[string]any) (types.Map, diag.Diagnostics) {
+	elements := make(map[string]attr.Value, len(value))
+	for key, element := range value {
+		switch element := element.(type) {
+		case string:
+			elements[key] = types.StringValue(element)
+		case nil:
+			elements[key] = types.StringNull()
+		default:
+			elements[key] = types.StringValue(fmt.Sprint(element))
+		}
+	}
+	return types.MapValue(types.StringType, elements)
+}
@@ -0,0 +1,143 @@
+package ironic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// defaultPowerStateTimeout bounds power state changes when the context
+	// carries no deadline of its own.
+	defaultPowerStateTimeout = 10 * time.Minute
+	// maxPowerStateGetFailures is how many times in a row the node may fail
+	// to be read while waiting for its power state.
+	maxPowerStateGetFailures = 5
+)
+
+// powerStatePollInterval is how often changePowerState checks on the node.
+var powerStatePollInterval = 5 * time.Second
+
+// powerTargets maps the power state targets accepted by the provider to the
+// power state the node is in once they are done.
+var powerTargets = map[nodes.TargetPowerState]string{
+	nodes.PowerOn:       "power on",
+	nodes.PowerOff:      "power off",
+	nodes.Rebooting:     "power on",
+	nodes.SoftPowerOff:  "power off",
+	nodes.SoftRebooting: "power on",
+}
+
+// isSoftPowerTarget reports whether the target needs a soft power action,
+// which like the power timeout requires microversion 1.27.
+func isSoftPowerTarget(target string) bool {
+	return strings.HasPrefix(target, "soft ")
+}
+
+// changePowerState requests a power state change of the node and waits until
+// Ironic is done with it, or the context deadline expires, for at most 10
+// minutes unless the context has an earlier deadline. The timeout is passed
+// on to Ironic when not zero.
+func changePowerState(
+	ctx context.Context,
+	client *gophercloud.ServiceClient,
+	nodeID string,
+	target nodes.TargetPowerState,
+	timeout int,
+) error {
+	expected, ok := powerTargets[target]
+	if !ok {
+		return fmt.Errorf("unknown power state target: %s", target)
+	}
+
+	ctx, cancel, waitTimeout := withDefaultTimeout(ctx, defaultPowerStateTimeout)
+	defer cancel()
+
+	tflog.Info(ctx, "Changing power state", map[string]any{
+		"node_id": nodeID,
+		"target":  string(target),
+	})
+
+	err := nodes.ChangePowerState(ctx, client, nodeID, nodes.PowerStateOpts{
+		Target:  target,
+		Timeout: timeout,
+	}).ExtractErr()
+	if err != nil {
+		return fmt.Errorf("failed to change power state to '%s': %w", target, err)
+	}
+
+	ticker := time.NewTicker(powerStatePollInterval)
+	defer ticker.Stop()
+
+	failures := 0
+	for {
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("timeout waiting for node %s to reach power state '%s' after %v",
+					nodeID, expected, waitTimeout)
+			}
+			return fmt.Errorf("context cancelled while waiting for power state: %w", ctx.Err())
+
+		case <-ticker.C:
+			node, err := nodes.Get(ctx, client, nodeID).Extract()
+			if err != nil {
+				failures++
+				if failures >= maxPowerStateGetFailures {
+					return fmt.Errorf("could not get node %s while waiting for power state '%s': %w",
+						nodeID, expected, err)
+				}
+				tflog.Warn(ctx, "Failed to get node during power state wait", map[string]any{
+					"node_id": nodeID,
+					"error":   err.Error(),
+				})
+				continue
+			}
+			failures = 0
+
+			tflog.Debug(ctx, "Checking power state", map[string]any{
+				"node_id":            nodeID,
+				"power_state":        node.PowerState,
+				"target_power_state": node.TargetPowerState,
+			})
+
+			// Ironic clears the target once the action is over
+			if node.TargetPowerState != "" {
+				continue
+			}
+			if node.PowerState == expected {
+				return nil
+			}
+
+			errorMsg := "unknown error"
+			if node.LastError != "" {
+				errorMsg = node.LastError
+			}
+			return fmt.Errorf("node %s is in power state '%s' instead of '%s': %s",
+				nodeID, node.PowerState, expected, errorMsg)
+		}
+	}
+}
+
+// readTargetPowerState returns the value of target_power_state after a
+// refresh. A configured target is kept while the node is in, or on its way
+// to, the power state it leads to. Otherwise the actual power state is
+// reported so the drift shows up in the plan. Without a configured target,
+// the transition Ironic is working on, if any, is reported.
+func readTargetPowerState(current types.String, node *nodes.Node) types.String {
+	expected, ok := powerTargets[nodes.TargetPowerState(current.ValueString())]
+	if !ok {
+		return types.StringValue(node.TargetPowerState)
+	}
+	if node.TargetPowerState != "" || node.PowerState == expected {
+		return current
+	}
+	return types.StringValue(node.PowerState)
+}
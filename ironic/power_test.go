@@ -0,0 +1,161 @@
+package ironic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/noauth"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	th "github.com/metal3-community/terraform-provider-ironic/testhelper"
+)
+
+// newPowerIronic returns a client for a fake Ironic that takes one poll to
+// apply a power state change. Failing targets leave the node as it was with
+// a last error.
+func newPowerIronic(t *testing.T, failing nodes.TargetPowerState) *gophercloud.ServiceClient {
+	t.Helper()
+
+	powerState, target, lastError := "power off", "", ""
+	var requested map[string]any
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/nodes/node/states/power", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&requested); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		target = requested["target"].(string)
+		w.WriteHeader(http.StatusAccepted)
+	})
+	mux.HandleFunc("/v1/nodes/node", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"uuid": "node", "power_state": %q, "target_power_state": %q, "last_error": %q}`,
+			powerState, target, lastError)
+
+		// Complete the change after it has been seen in progress once
+		switch {
+		case target == "":
+		case nodes.TargetPowerState(target) == failing:
+			lastError = "BMC unreachable"
+		default:
+			powerState = powerTargets[nodes.TargetPowerState(target)]
+		}
+		target = ""
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := noauth.NewBareMetalNoAuth(noauth.EndpointOpts{
+		IronicEndpoint: server.URL + "/v1",
+	})
+	th.AssertNoError(t, err)
+
+	return client
+}
+
+func TestChangePowerState(t *testing.T) {
+	defer func(interval time.Duration) { powerStatePollInterval = interval }(powerStatePollInterval)
+	powerStatePollInterval = 10 * time.Millisecond
+
+	ctx := context.Background()
+
+	client := newPowerIronic(t, nodes.SoftPowerOff)
+	th.AssertNoError(t, changePowerState(ctx, client, "node", nodes.PowerOn, 0))
+	th.AssertNoError(t, changePowerState(ctx, client, "node", nodes.Rebooting, 60))
+
+	err := changePowerState(ctx, client, "node", nodes.SoftPowerOff, 0)
+	th.AssertError(t, err, "is in power state 'power on' instead of 'power off': BMC unreachable")
+
+	// The node is never checked again before the deadline
+	powerStatePollInterval = time.Hour
+	timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	err = changePowerState(timeoutCtx, client, "node", nodes.PowerOff, 0)
+	th.AssertError(t, err, "timeout waiting for node node to reach power state 'power off'")
+}
+
+func TestChangePowerStateUnreachable(t *testing.T) {
+	defer func(interval time.Duration) { powerStatePollInterval = interval }(powerStatePollInterval)
+	powerStatePollInterval = 10 * time.Millisecond
+
+	gets := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/nodes/node/states/power", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	})
+	mux.HandleFunc("/v1/nodes/node", func(w http.ResponseWriter, r *http.Request) {
+		gets++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := noauth.NewBareMetalNoAuth(noauth.EndpointOpts{
+		IronicEndpoint: server.URL + "/v1",
+	})
+	th.AssertNoError(t, err)
+
+	// Without a deadline, repeated failures to read the node end the wait
+	err = changePowerState(context.Background(), client, "node", nodes.PowerOn, 0)
+	th.AssertError(t, err, "could not get node node while waiting for power state 'power on'")
+	if gets != maxPowerStateGetFailures {
+		t.Errorf("expected %d reads of the node, got %d", maxPowerStateGetFailures, gets)
+	}
+}
+
+func TestReadTargetPowerState(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  types.String
+		node     nodes.Node
+		expected string
+	}{
+		{
+			name:     "reached",
+			current:  types.StringValue("power on"),
+			node:     nodes.Node{PowerState: "power on"},
+			expected: "power on",
+		},
+		{
+			name:     "reboot done",
+			current:  types.StringValue("rebooting"),
+			node:     nodes.Node{PowerState: "power on"},
+			expected: "rebooting",
+		},
+		{
+			name:     "in progress",
+			current:  types.StringValue("soft power off"),
+			node:     nodes.Node{PowerState: "power on", TargetPowerState: "power off"},
+			expected: "soft power off",
+		},
+		{
+			name:     "drift",
+			current:  types.StringValue("power on"),
+			node:     nodes.Node{PowerState: "power off"},
+			expected: "power off",
+		},
+		{
+			name:     "not configured",
+			current:  types.StringUnknown(),
+			node:     nodes.Node{PowerState: "power off", TargetPowerState: "power on"},
+			expected: "power on",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := readTargetPowerState(test.current, &test.node)
+			if actual.ValueString() != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, actual.ValueString())
+			}
+		})
+	}
+}
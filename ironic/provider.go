@@ -2,38 +2,86 @@ package ironic
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack"
 	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/apiversions"
 	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/httpbasic"
 	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/noauth"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/conductors"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/metal3-community/terraform-provider-ironic/ironic/util/retry"
 )
 
 // Meta stores the client connection information for Ironic.
 type Meta struct {
 	Client *gophercloud.ServiceClient
+
+	// Inspector is the Ironic Inspector client, nil when no inspector is
+	// configured.
+	Inspector *gophercloud.ServiceClient
+
+	// MaxMicroversion is the highest microversion supported by the Ironic
+	// API, if it advertises one.
+	MaxMicroversion string
+
+	// drivers caches the drivers read to validate driver_info during
+	// planning, by name.
+	driversMu sync.Mutex
+	drivers   map[string]driverModel
+
+	// conductorList caches the conductors listed to validate conductor_group
+	// during planning.
+	conductorsMu  sync.Mutex
+	conductorList []conductors.Conductor
 }
 
 // Shared descriptions for provider attributes to ensure consistency.
 var frameworkDescriptions = map[string]string{
-	"url":                "The authentication endpoint for Ironic",
-	"inspector":          "The endpoint for Ironic inspector",
-	"microversion":       "The microversion to use for Ironic",
-	"timeout":            "Wait at least the specified number of seconds for the API to become available",
-	"auth_strategy":      "Determine the strategy to use for authentication with Ironic services, Possible values: noauth, http_basic. Defaults to noauth.",
-	"ironic_username":    "Username to be used by Ironic when using `http_basic` authentication",
-	"ironic_password":    "Password to be used by Ironic when using `http_basic` authentication",
-	"inspector_username": "Username to be used by Ironic Inspector when using `http_basic` authentication",
-	"inspector_password": "Password to be used by Ironic Inspector when using `http_basic` authentication",
+	"cloud":                         "The name of the cloud entry in clouds.yaml to read the endpoint, credentials, CA bundle and microversion from. Explicitly configured attributes take precedence",
+	"url":                           "The authentication endpoint for Ironic. Optional when using `keystone` authentication, in which case the endpoint is discovered from the service catalog",
+	"inspector":                     "The endpoint for Ironic inspector. With `keystone` authentication it is discovered from the service catalog when not set",
+	"microversion":                  "The microversion to use for Ironic, or `auto` to use the highest one supported by the server. Defaults to `auto`",
+	"timeout":                       "Wait at least the specified number of seconds for the API to become available",
+	"auth_strategy":                 "Determine the strategy to use for authentication with Ironic services, Possible values: noauth, http_basic, keystone. Defaults to noauth.",
+	"ironic_username":               "Username to be used by Ironic when using `http_basic` or `keystone` authentication",
+	"ironic_password":               "Password to be used by Ironic when using `http_basic` or `keystone` authentication",
+	"inspector_username":            "Username to be used by Ironic Inspector when using `http_basic` authentication",
+	"inspector_password":            "Password to be used by Ironic Inspector when using `http_basic` authentication",
+	"auth_url":                      "The Keystone identity endpoint used when `auth_strategy` is `keystone`",
+	"user_domain_name":              "The domain name of the Keystone user",
+	"user_domain_id":                "The domain ID of the Keystone user",
+	"project_name":                  "The name of the Keystone project to scope to",
+	"project_id":                    "The ID of the Keystone project to scope to",
+	"project_domain_name":           "The domain name of the Keystone project",
+	"project_domain_id":             "The domain ID of the Keystone project",
+	"application_credential_id":     "The ID of a Keystone application credential to authenticate with",
+	"application_credential_name":   "The name of a Keystone application credential to authenticate with",
+	"application_credential_secret": "The secret of the Keystone application credential",
+	"region":                        "The region used to look up the Ironic endpoint in the Keystone service catalog",
+	"interface":                     "The endpoint interface used to look up the Ironic endpoint in the Keystone service catalog, Possible values: public, internal, admin. Defaults to public.",
+	"cacert_file":                   "Path to a PEM encoded CA bundle used to verify the Ironic, Inspector and Keystone endpoints",
+	"cert_file":                     "Path to a PEM encoded client certificate for mutual TLS",
+	"key_file":                      "Path to the PEM encoded private key of the client certificate",
+	"insecure":                      "Skip verification of the server TLS certificate. Defaults to false.",
 }
 
 // IronicProvider is a type that implements the terraform-plugin-framework
@@ -49,15 +97,32 @@ var (
 // IronicProviderModel is a helper type for extracting the provider
 // configuration from the provider block.
 type IronicProviderModel struct {
-	Url               types.String `tfsdk:"url"`
-	Inspector         types.String `tfsdk:"inspector"`
-	Microversion      types.String `tfsdk:"microversion"`
-	Timeout           types.Int64  `tfsdk:"timeout"`
-	AuthStrategy      types.String `tfsdk:"auth_strategy"`
-	IronicUsername    types.String `tfsdk:"ironic_username"`
-	IronicPassword    types.String `tfsdk:"ironic_password"`
-	InspectorUsername types.String `tfsdk:"inspector_username"`
-	InspectorPassword types.String `tfsdk:"inspector_password"`
+	Cloud                       types.String `tfsdk:"cloud"`
+	Url                         types.String `tfsdk:"url"`
+	Inspector                   types.String `tfsdk:"inspector"`
+	Microversion                types.String `tfsdk:"microversion"`
+	Timeout                     types.Int64  `tfsdk:"timeout"`
+	AuthStrategy                types.String `tfsdk:"auth_strategy"`
+	IronicUsername              types.String `tfsdk:"ironic_username"`
+	IronicPassword              types.String `tfsdk:"ironic_password"`
+	InspectorUsername           types.String `tfsdk:"inspector_username"`
+	InspectorPassword           types.String `tfsdk:"inspector_password"`
+	AuthURL                     types.String `tfsdk:"auth_url"`
+	UserDomainName              types.String `tfsdk:"user_domain_name"`
+	UserDomainID                types.String `tfsdk:"user_domain_id"`
+	ProjectName                 types.String `tfsdk:"project_name"`
+	ProjectID                   types.String `tfsdk:"project_id"`
+	ProjectDomainName           types.String `tfsdk:"project_domain_name"`
+	ProjectDomainID             types.String `tfsdk:"project_domain_id"`
+	ApplicationCredentialID     types.String `tfsdk:"application_credential_id"`
+	ApplicationCredentialName   types.String `tfsdk:"application_credential_name"`
+	ApplicationCredentialSecret types.String `tfsdk:"application_credential_secret"`
+	Region                      types.String `tfsdk:"region"`
+	Interface                   types.String `tfsdk:"interface"`
+	CACertFile                  types.String `tfsdk:"cacert_file"`
+	CertFile                    types.String `tfsdk:"cert_file"`
+	KeyFile                     types.String `tfsdk:"key_file"`
+	Insecure                    types.Bool   `tfsdk:"insecure"`
 }
 
 // New is a helper function for initializing the portion of
@@ -88,8 +153,12 @@ func (p *IronicProvider) Schema(
 ) {
 	res.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"cloud": schema.StringAttribute{
+				Optional:    true,
+				Description: frameworkDescriptions["cloud"],
+			},
 			"url": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
 				Description: frameworkDescriptions["url"],
 			},
 			"inspector": schema.StringAttribute{
@@ -97,8 +166,14 @@ func (p *IronicProvider) Schema(
 				Description: frameworkDescriptions["inspector"],
 			},
 			"microversion": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
 				Description: frameworkDescriptions["microversion"],
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^(auto|1\.\d+)$`),
+						"must be auto or a microversion such as 1.81",
+					),
+				},
 			},
 			"timeout": schema.Int64Attribute{
 				Optional:    true,
@@ -107,6 +182,9 @@ func (p *IronicProvider) Schema(
 			"auth_strategy": schema.StringAttribute{
 				Optional:    true,
 				Description: frameworkDescriptions["auth_strategy"],
+				Validators: []validator.String{
+					stringvalidator.OneOf("noauth", "http_basic", "keystone"),
+				},
 			},
 			"ironic_username": schema.StringAttribute{
 				Optional:    true,
@@ -126,6 +204,74 @@ func (p *IronicProvider) Schema(
 				Sensitive:   true,
 				Description: frameworkDescriptions["inspector_password"],
 			},
+			"auth_url": schema.StringAttribute{
+				Optional:    true,
+				Description: frameworkDescriptions["auth_url"],
+			},
+			"user_domain_name": schema.StringAttribute{
+				Optional:    true,
+				Description: frameworkDescriptions["user_domain_name"],
+			},
+			"user_domain_id": schema.StringAttribute{
+				Optional:    true,
+				Description: frameworkDescriptions["user_domain_id"],
+			},
+			"project_name": schema.StringAttribute{
+				Optional:    true,
+				Description: frameworkDescriptions["project_name"],
+			},
+			"project_id": schema.StringAttribute{
+				Optional:    true,
+				Description: frameworkDescriptions["project_id"],
+			},
+			"project_domain_name": schema.StringAttribute{
+				Optional:    true,
+				Description: frameworkDescriptions["project_domain_name"],
+			},
+			"project_domain_id": schema.StringAttribute{
+				Optional:    true,
+				Description: frameworkDescriptions["project_domain_id"],
+			},
+			"application_credential_id": schema.StringAttribute{
+				Optional:    true,
+				Description: frameworkDescriptions["application_credential_id"],
+			},
+			"application_credential_name": schema.StringAttribute{
+				Optional:    true,
+				Description: frameworkDescriptions["application_credential_name"],
+			},
+			"application_credential_secret": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: frameworkDescriptions["application_credential_secret"],
+			},
+			"region": schema.StringAttribute{
+				Optional:    true,
+				Description: frameworkDescriptions["region"],
+			},
+			"interface": schema.StringAttribute{
+				Optional:    true,
+				Description: frameworkDescriptions["interface"],
+				Validators: []validator.String{
+					stringvalidator.OneOf("public", "internal", "admin"),
+				},
+			},
+			"cacert_file": schema.StringAttribute{
+				Optional:    true,
+				Description: frameworkDescriptions["cacert_file"],
+			},
+			"cert_file": schema.StringAttribute{
+				Optional:    true,
+				Description: frameworkDescriptions["cert_file"],
+			},
+			"key_file": schema.StringAttribute{
+				Optional:    true,
+				Description: frameworkDescriptions["key_file"],
+			},
+			"insecure": schema.BoolAttribute{
+				Optional:    true,
+				Description: frameworkDescriptions["insecure"],
+			},
 		},
 	}
 }
@@ -148,59 +294,84 @@ func (p *IronicProvider) Configure(
 
 	tflog.Info(ctx, "Configuring Ironic provider")
 
-	// Get URL with environment variable fallback
-	url := data.Url.ValueString()
-	if url == "" {
-		if v := os.Getenv("IRONIC_ENDPOINT"); v != "" {
-			url = v
+	// Fill anything not configured explicitly from clouds.yaml
+	if cloudName := stringValueOrEnv(data.Cloud, "OS_CLOUD"); cloudName != "" {
+		tflog.Debug(ctx, "Loading cloud from clouds.yaml", map[string]any{"cloud": cloudName})
+		cloud, err := loadCloud(cloudName)
+		if err != nil {
+			res.Diagnostics.AddError(
+				"Could not load clouds.yaml",
+				fmt.Sprintf("Error: %s", err.Error()),
+			)
+			return
+		}
+		if err := applyCloud(&data, cloud); err != nil {
+			res.Diagnostics.AddError(
+				"Invalid cloud configuration",
+				fmt.Sprintf("Cloud %s: %s", cloudName, err.Error()),
+			)
+			return
 		}
 	}
-	if url == "" {
+
+	tlsOpts, err := tlsOptions(data)
+	if err != nil {
 		res.Diagnostics.AddError(
-			"Missing Ironic URL",
-			"The 'url' field is required for the Ironic provider.",
+			"Invalid TLS configuration",
+			fmt.Sprintf("Error: %s", err.Error()),
 		)
 		return
 	}
-	tflog.Debug(ctx, "Setting up ironic endpoint", map[string]any{"url": url})
+
+	httpClient, err := newHTTPClient(tlsOpts)
+	if err != nil {
+		res.Diagnostics.AddError(
+			"Could not configure TLS",
+			fmt.Sprintf("Error: %s", err.Error()),
+		)
+		return
+	}
+
+	// Get URL with environment variable fallback
+	url := stringValueOrEnv(data.Url, "IRONIC_ENDPOINT")
 
 	// Get microversion with environment variable fallback and default
-	microversion := data.Microversion.ValueString()
+	microversion := stringValueOrEnv(data.Microversion, "IRONIC_MICROVERSION")
 	if microversion == "" {
-		if v := os.Getenv("IRONIC_MICROVERSION"); v != "" {
-			microversion = v
-		} else {
-			microversion = "1.99"
-		}
+		microversion = MicroversionAuto
 	}
 
 	// Get auth strategy with environment variable fallback and default
-	authStrategy := data.AuthStrategy.ValueString()
+	authStrategy := stringValueOrEnv(data.AuthStrategy, "IRONIC_AUTH_STRATEGY")
 	if authStrategy == "" {
-		if v := os.Getenv("IRONIC_AUTH_STRATEGY"); v != "" {
-			authStrategy = v
-		} else {
-			authStrategy = "noauth"
-		}
+		authStrategy = "noauth"
+	}
+
+	// With Keystone the endpoint can be discovered from the service catalog,
+	// every other strategy needs it spelled out.
+	if url == "" && authStrategy != "keystone" {
+		res.Diagnostics.AddError(
+			"Missing Ironic URL",
+			"The 'url' field is required for the Ironic provider.",
+		)
+		return
 	}
+	tflog.Debug(ctx, "Setting up ironic endpoint", map[string]any{"url": url})
+
+	// Only used for catalog lookups with keystone
+	var endpointOpts gophercloud.EndpointOpts
+
+	// Keystone and the Ironic API may still be starting up, both are retried
+	// until the timeout expires
+	timeout := time.Duration(data.Timeout.ValueInt64()) * time.Second
 
-	if authStrategy == "http_basic" {
+	switch authStrategy {
+	case "http_basic":
 		tflog.Debug(ctx, "Using http_basic auth_strategy")
 
 		// Get Ironic credentials with environment variable fallback
-		ironicUser := data.IronicUsername.ValueString()
-		if ironicUser == "" {
-			if v := os.Getenv("IRONIC_HTTP_BASIC_USERNAME"); v != "" {
-				ironicUser = v
-			}
-		}
-
-		ironicPassword := data.IronicPassword.ValueString()
-		if ironicPassword == "" {
-			if v := os.Getenv("IRONIC_HTTP_BASIC_PASSWORD"); v != "" {
-				ironicPassword = v
-			}
-		}
+		ironicUser := stringValueOrEnv(data.IronicUsername, "IRONIC_HTTP_BASIC_USERNAME")
+		ironicPassword := stringValueOrEnv(data.IronicPassword, "IRONIC_HTTP_BASIC_PASSWORD")
 
 		ironic, err := httpbasic.NewBareMetalHTTPBasic(httpbasic.EndpointOpts{
 			IronicEndpoint:     url,
@@ -215,10 +386,34 @@ func (p *IronicProvider) Configure(
 			return
 		}
 
-		ironic.Microversion = microversion
+		ironic.HTTPClient = httpClient
 		meta.Client = ironic
 
-	} else {
+	case "keystone":
+		tflog.Debug(ctx, "Using keystone auth_strategy")
+
+		var authOpts gophercloud.AuthOptions
+		authOpts, endpointOpts = keystoneOptions(data)
+		if authOpts.IdentityEndpoint == "" {
+			res.Diagnostics.AddError(
+				"Missing Keystone auth URL",
+				"The 'auth_url' field is required when using the keystone auth_strategy.",
+			)
+			return
+		}
+
+		ironic, err := newKeystoneClient(ctx, httpClient, authOpts, endpointOpts, url, timeout)
+		if err != nil {
+			res.Diagnostics.AddError(
+				"Could not configure Ironic endpoint",
+				fmt.Sprintf("Error: %s", err.Error()),
+			)
+			return
+		}
+
+		meta.Client = ironic
+
+	default:
 		tflog.Debug(ctx, "Using noauth auth_strategy")
 		ironic, err := noauth.NewBareMetalNoAuth(noauth.EndpointOpts{
 			IronicEndpoint: url,
@@ -230,11 +425,21 @@ func (p *IronicProvider) Configure(
 			)
 			return
 		}
-		ironic.Microversion = microversion
+		ironic.HTTPClient = httpClient
 		meta.Client = ironic
 	}
 
-	if err := healthCheck(ctx, meta.Client); err != nil {
+	inspector, err := newInspectorClient(data, authStrategy, meta.Client, endpointOpts, httpClient)
+	if err != nil {
+		res.Diagnostics.AddError(
+			"Could not configure Ironic Inspector endpoint",
+			fmt.Sprintf("Error: %s", err.Error()),
+		)
+		return
+	}
+	meta.Inspector = inspector
+
+	if err := waitForAPI(ctx, meta.Client, timeout); err != nil {
 		res.Diagnostics.AddError(
 			"Ironic API health check failed",
 			fmt.Sprintf("Error: %s", err.Error()),
@@ -242,6 +447,21 @@ func (p *IronicProvider) Configure(
 		return
 	}
 
+	negotiated, maxMicroversion, err := negotiateMicroversion(ctx, meta.Client, microversion)
+	if err != nil {
+		res.Diagnostics.AddError(
+			"Could not negotiate Ironic API microversion",
+			fmt.Sprintf("Error: %s", err.Error()),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Using Ironic API microversion", map[string]any{
+		"microversion": negotiated,
+		"max":          maxMicroversion,
+	})
+	meta.Client.Microversion = negotiated
+	meta.MaxMicroversion = maxMicroversion
+
 	res.DataSourceData = &meta
 	res.ResourceData = &meta
 	res.EphemeralResourceData = &meta
@@ -250,6 +470,17 @@ func (p *IronicProvider) Configure(
 func (p *IronicProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewNodeInventoryDataSource,
+		NewIntrospectionDataSource,
+		NewNodeDataSource,
+		NewNodesDataSource,
+		NewPortDataSource,
+		NewPortsDataSource,
+		NewPortGroupDataSource,
+		NewPortGroupsDataSource,
+		NewDriverDataSource,
+		NewDriversDataSource,
+		NewConductorDataSource,
+		NewConductorsDataSource,
 	}
 }
 
@@ -260,6 +491,10 @@ func (p *IronicProvider) Resources(ctx context.Context) []func() resource.Resour
 		NewPortV1Resource,
 		NewAllocationV1Resource,
 		NewDeploymentResource,
+		NewIntrospectionRuleResource,
+		NewNodeBIOSSettingsResource,
+		NewNodeRAIDConfigResource,
+		NewNodeServiceResource,
 	}
 }
 
@@ -269,6 +504,219 @@ func (p *IronicProvider) EphemeralResources(
 	return []func() ephemeral.EphemeralResource{}
 }
 
+// stringValueOrEnv returns the configured value of an attribute, falling back
+// to the first non-empty environment variable from envVars.
+func stringValueOrEnv(value types.String, envVars ...string) string {
+	if v := value.ValueString(); v != "" {
+		return v
+	}
+	for _, envVar := range envVars {
+		if v := os.Getenv(envVar); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// keystoneOptions builds the Keystone authentication and endpoint discovery
+// options from the provider configuration and the standard OS_* env vars.
+func keystoneOptions(data IronicProviderModel) (gophercloud.AuthOptions, gophercloud.EndpointOpts) {
+	authOpts := gophercloud.AuthOptions{
+		IdentityEndpoint:            stringValueOrEnv(data.AuthURL, "OS_AUTH_URL"),
+		Username:                    stringValueOrEnv(data.IronicUsername, "OS_USERNAME"),
+		Password:                    stringValueOrEnv(data.IronicPassword, "OS_PASSWORD"),
+		DomainName:                  stringValueOrEnv(data.UserDomainName, "OS_USER_DOMAIN_NAME"),
+		DomainID:                    stringValueOrEnv(data.UserDomainID, "OS_USER_DOMAIN_ID"),
+		ApplicationCredentialID:     stringValueOrEnv(data.ApplicationCredentialID, "OS_APPLICATION_CREDENTIAL_ID"),
+		ApplicationCredentialName:   stringValueOrEnv(data.ApplicationCredentialName, "OS_APPLICATION_CREDENTIAL_NAME"),
+		ApplicationCredentialSecret: stringValueOrEnv(data.ApplicationCredentialSecret, "OS_APPLICATION_CREDENTIAL_SECRET"),
+		AllowReauth:                 true,
+	}
+
+	// Application credentials carry their own scope, Keystone rejects
+	// requests that try to scope them again.
+	if authOpts.ApplicationCredentialID == "" && authOpts.ApplicationCredentialName == "" {
+		scope := gophercloud.AuthScope{
+			ProjectID:   stringValueOrEnv(data.ProjectID, "OS_PROJECT_ID", "OS_TENANT_ID"),
+			ProjectName: stringValueOrEnv(data.ProjectName, "OS_PROJECT_NAME", "OS_TENANT_NAME"),
+			DomainID:    stringValueOrEnv(data.ProjectDomainID, "OS_PROJECT_DOMAIN_ID"),
+			DomainName:  stringValueOrEnv(data.ProjectDomainName, "OS_PROJECT_DOMAIN_NAME"),
+		}
+		// Projects referenced by name live in the user's domain unless told
+		// otherwise.
+		if scope.ProjectName != "" && scope.DomainID == "" && scope.DomainName == "" {
+			scope.DomainID = authOpts.DomainID
+			scope.DomainName = authOpts.DomainName
+		}
+		if scope.ProjectID != "" || scope.ProjectName != "" {
+			authOpts.Scope = &scope
+		}
+	}
+
+	endpointOpts := gophercloud.EndpointOpts{
+		Region:       stringValueOrEnv(data.Region, "OS_REGION_NAME"),
+		Availability: gophercloud.Availability(stringValueOrEnv(data.Interface, "OS_INTERFACE")),
+	}
+	if endpointOpts.Availability == "" {
+		endpointOpts.Availability = gophercloud.AvailabilityPublic
+	}
+
+	return authOpts, endpointOpts
+}
+
+// clientTLSOptions holds the TLS settings applied to the HTTP client used to
+// talk to Ironic and Keystone.
+type clientTLSOptions struct {
+	CACertFile string
+	CertFile   string
+	KeyFile    string
+	Insecure   bool
+}
+
+// tlsOptions collects the TLS settings from the provider configuration, with
+// environment variable fallbacks.
+func tlsOptions(data IronicProviderModel) (clientTLSOptions, error) {
+	opts := clientTLSOptions{
+		CACertFile: stringValueOrEnv(data.CACertFile, "IRONIC_CACERT_FILE", "OS_CACERT"),
+		CertFile:   stringValueOrEnv(data.CertFile, "IRONIC_CERT_FILE", "OS_CERT"),
+		KeyFile:    stringValueOrEnv(data.KeyFile, "IRONIC_KEY_FILE", "OS_KEY"),
+	}
+
+	if !data.Insecure.IsNull() {
+		opts.Insecure = data.Insecure.ValueBool()
+	} else if v := stringValueOrEnv(types.StringNull(), "IRONIC_INSECURE", "OS_INSECURE"); v != "" {
+		insecure, err := strconv.ParseBool(v)
+		if err != nil {
+			return opts, fmt.Errorf("invalid value %q for insecure: %w", v, err)
+		}
+		opts.Insecure = insecure
+	}
+
+	return opts, nil
+}
+
+// newHTTPClient returns an HTTP client honoring the given TLS options. The
+// zero value of clientTLSOptions yields the default client.
+func newHTTPClient(opts clientTLSOptions) (http.Client, error) {
+	if opts == (clientTLSOptions{}) {
+		return http.Client{}, nil
+	}
+
+	// #nosec G402 -- InsecureSkipVerify is an explicit user choice
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: opts.Insecure,
+	}
+
+	if opts.CACertFile != "" {
+		caCert, err := os.ReadFile(opts.CACertFile)
+		if err != nil {
+			return http.Client{}, fmt.Errorf("failed to read CA bundle %s: %w", opts.CACertFile, err)
+		}
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(caCert) {
+			return http.Client{}, fmt.Errorf("no certificates found in CA bundle %s", opts.CACertFile)
+		}
+		tlsConfig.RootCAs = caCertPool
+	}
+
+	if opts.CertFile != "" || opts.KeyFile != "" {
+		if opts.CertFile == "" || opts.KeyFile == "" {
+			return http.Client{}, fmt.Errorf("both a client certificate and key are required")
+		}
+		cert, err := tls.LoadX509KeyPair(opts.CertFile, opts.KeyFile)
+		if err != nil {
+			return http.Client{}, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	return http.Client{Transport: transport}, nil
+}
+
+// newKeystoneClient authenticates against Keystone and returns a baremetal
+// ServiceClient. When endpoint is empty, the Ironic endpoint is looked up in
+// the service catalog using endpointOpts. Authentication is retried while
+// Keystone is unavailable, until the timeout expires.
+func newKeystoneClient(
+	ctx context.Context,
+	httpClient http.Client,
+	authOpts gophercloud.AuthOptions,
+	endpointOpts gophercloud.EndpointOpts,
+	endpoint string,
+	timeout time.Duration,
+) (*gophercloud.ServiceClient, error) {
+	provider, err := openstack.NewClient(authOpts.IdentityEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create identity client: %w", err)
+	}
+	provider.HTTPClient = httpClient
+
+	err = retryUnavailable(ctx, timeout, "Keystone", func() error {
+		return openstack.Authenticate(ctx, provider, authOpts)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate with keystone: %w", err)
+	}
+
+	if endpoint != "" {
+		return &gophercloud.ServiceClient{
+			ProviderClient: provider,
+			Endpoint:       gophercloud.NormalizeURL(endpoint),
+			Type:           "baremetal",
+		}, nil
+	}
+
+	client, err := openstack.NewBareMetalV1(provider, endpointOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find Ironic endpoint in service catalog: %w", err)
+	}
+	tflog.Debug(ctx, "Discovered ironic endpoint", map[string]any{"url": client.Endpoint})
+
+	return client, nil
+}
+
+// errAPINotCurrent is returned by healthCheck when Ironic does not serve v1
+// as its current API version, which retrying won't fix.
+var errAPINotCurrent = errors.New("API version is not current")
+
+// waitForAPI runs healthCheck until it succeeds or the timeout expires, backing
+// off between attempts. A zero timeout checks exactly once.
+func waitForAPI(ctx context.Context, client *gophercloud.ServiceClient, timeout time.Duration) error {
+	return retryUnavailable(ctx, timeout, "Ironic API", func() error {
+		return healthCheck(ctx, client)
+	})
+}
+
+// retryUnavailable runs f until it succeeds or the timeout expires, backing
+// off between attempts. Authentication failures and an Ironic API that is not
+// current are returned immediately, as they won't go away by waiting. A zero
+// timeout runs f exactly once.
+func retryUnavailable(ctx context.Context, timeout time.Duration, service string, f func() error) error {
+	if timeout <= 0 {
+		return f()
+	}
+
+	return retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		err := f()
+		if err == nil {
+			return nil
+		}
+		if gophercloud.ResponseCodeIs(err, http.StatusUnauthorized) ||
+			gophercloud.ResponseCodeIs(err, http.StatusForbidden) ||
+			errors.Is(err, errAPINotCurrent) {
+			return retry.NonRetryableError(err)
+		}
+		tflog.Debug(ctx, service+" is not available yet, retrying", map[string]any{
+			"error": err.Error(),
+		})
+		return retry.RetryableError(err)
+	})
+}
+
 func healthCheck(ctx context.Context, client *gophercloud.ServiceClient) error {
 	apiversionListResp, err := apiversions.List(ctx, client).Extract()
 	if err != nil {
@@ -281,8 +729,8 @@ func healthCheck(ctx context.Context, client *gophercloud.ServiceClient) error {
 				"version": apiversion.Version,
 				"status":  apiversion.Status,
 			})
-			return fmt.Errorf("ironic API health check failed: API version %s is not current",
-				apiversion.Version)
+			return fmt.Errorf("ironic API health check failed: %w: %s",
+				errAPINotCurrent, apiversion.Version)
 		}
 	}
 	// If we reach here, the API is considered healthy.
@@ -0,0 +1,590 @@
+package ironic
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/noauth"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	th "github.com/metal3-community/terraform-provider-ironic/testhelper"
+)
+
+const fakeToken = "fake-keystone-token"
+
+// newFakeKeystone serves a minimal Keystone v3 token API whose service
+// catalog points the baremetal service at the same server, plus just enough
+// of the Ironic API for healthCheck and node listing.
+func newFakeKeystone(t *testing.T) (*httptest.Server, *map[string]any) {
+	t.Helper()
+
+	var lastAuth map[string]any
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/identity/v3/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&lastAuth); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Subject-Token", fakeToken)
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{
+			"token": {
+				"expires_at": "2099-01-01T00:00:00.000000Z",
+				"catalog": [{
+					"type": "baremetal",
+					"name": "ironic",
+					"endpoints": [
+						{"id": "1", "interface": "public", "region": "RegionOne", "region_id": "RegionOne", "url": "%[1]s/public"},
+						{"id": "2", "interface": "internal", "region": "RegionOne", "region_id": "RegionOne", "url": "%[1]s/internal"},
+						{"id": "3", "interface": "public", "region": "RegionTwo", "region_id": "RegionTwo", "url": "%[1]s/region-two"}
+					]
+				}]
+			}
+		}`, server.URL)
+	})
+
+	ironicHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Auth-Token") != fakeToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/public/v1/nodes", "/internal/v1/nodes", "/region-two/v1/nodes":
+			fmt.Fprint(w, `{"nodes": [{"uuid": "1be26c0b-03f2-4d2e-ae87-c02d7f33c123"}]}`)
+		default:
+			fmt.Fprint(w, `{"versions": [{"id": "v1", "status": "CURRENT", "version": "1.99"}]}`)
+		}
+	}
+	mux.HandleFunc("/public/", ironicHandler)
+	mux.HandleFunc("/internal/", ironicHandler)
+	mux.HandleFunc("/region-two/", ironicHandler)
+
+	return server, &lastAuth
+}
+
+func TestNewKeystoneClientCatalogDiscovery(t *testing.T) {
+	server, _ := newFakeKeystone(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		endpointOpts gophercloud.EndpointOpts
+		endpoint     string
+		expected     string
+	}{
+		{
+			name:         "public interface",
+			endpointOpts: gophercloud.EndpointOpts{Availability: gophercloud.AvailabilityPublic},
+			expected:     server.URL + "/public/v1/",
+		},
+		{
+			name:         "internal interface",
+			endpointOpts: gophercloud.EndpointOpts{Availability: gophercloud.AvailabilityInternal},
+			expected:     server.URL + "/internal/v1/",
+		},
+		{
+			name: "region",
+			endpointOpts: gophercloud.EndpointOpts{
+				Region:       "RegionTwo",
+				Availability: gophercloud.AvailabilityPublic,
+			},
+			expected: server.URL + "/region-two/v1/",
+		},
+		{
+			name:         "explicit endpoint",
+			endpointOpts: gophercloud.EndpointOpts{Availability: gophercloud.AvailabilityPublic},
+			endpoint:     server.URL + "/internal/v1",
+			expected:     server.URL + "/internal/v1/",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			authOpts := gophercloud.AuthOptions{
+				IdentityEndpoint: server.URL + "/identity/v3",
+				Username:         "admin",
+				Password:         "secret",
+				DomainName:       "Default",
+			}
+
+			client, err := newKeystoneClient(ctx, http.Client{}, authOpts, test.endpointOpts, test.endpoint, 0)
+			th.AssertNoError(t, err)
+
+			if url := client.ServiceURL("nodes"); url != test.expected+"nodes" {
+				t.Errorf("ServiceURL(nodes) = %s, expected %s", url, test.expected+"nodes")
+			}
+
+			th.AssertNoError(t, healthCheck(ctx, client))
+
+			allPages, err := nodes.List(client, nodes.ListOpts{}).AllPages(ctx)
+			th.AssertNoError(t, err)
+			nodeList, err := nodes.ExtractNodes(allPages)
+			th.AssertNoError(t, err)
+			if len(nodeList) != 1 {
+				t.Errorf("expected 1 node, got %d", len(nodeList))
+			}
+		})
+	}
+}
+
+func TestNewKeystoneClientMissingEndpoint(t *testing.T) {
+	server, _ := newFakeKeystone(t)
+
+	authOpts := gophercloud.AuthOptions{
+		IdentityEndpoint: server.URL + "/identity/v3",
+		Username:         "admin",
+		Password:         "secret",
+		DomainName:       "Default",
+	}
+	endpointOpts := gophercloud.EndpointOpts{
+		Region:       "RegionThree",
+		Availability: gophercloud.AvailabilityPublic,
+	}
+
+	_, err := newKeystoneClient(context.Background(), http.Client{}, authOpts, endpointOpts, "", 0)
+	th.AssertError(t, err, "service catalog")
+}
+
+func TestNewKeystoneClientRetry(t *testing.T) {
+	keystone, _ := newFakeKeystone(t)
+	target, err := url.Parse(keystone.URL)
+	th.AssertNoError(t, err)
+
+	// Keystone answers once the first two token requests have failed
+	var requests atomic.Int32
+	proxy := httputil.NewSingleHostReverseProxy(target)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		proxy.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	authOpts := gophercloud.AuthOptions{
+		IdentityEndpoint: server.URL + "/identity/v3",
+		Username:         "admin",
+		Password:         "secret",
+		DomainName:       "Default",
+	}
+	endpointOpts := gophercloud.EndpointOpts{
+		Region:       "RegionOne",
+		Availability: gophercloud.AvailabilityPublic,
+	}
+
+	_, err = newKeystoneClient(context.Background(), http.Client{}, authOpts, endpointOpts, "", time.Minute)
+	th.AssertNoError(t, err)
+	if got := requests.Load(); got != 3 {
+		t.Errorf("expected 3 token requests, got %d", got)
+	}
+}
+
+func TestKeystoneOptionsScoping(t *testing.T) {
+	server, lastAuth := newFakeKeystone(t)
+
+	data := IronicProviderModel{
+		AuthURL:        types.StringValue(server.URL + "/identity/v3"),
+		IronicUsername: types.StringValue("admin"),
+		IronicPassword: types.StringValue("secret"),
+		UserDomainName: types.StringValue("users"),
+		ProjectName:    types.StringValue("baremetal"),
+		Interface:      types.StringValue("internal"),
+	}
+
+	authOpts, endpointOpts := keystoneOptions(data)
+	if endpointOpts.Availability != gophercloud.AvailabilityInternal {
+		t.Errorf("expected internal availability, got %s", endpointOpts.Availability)
+	}
+	if authOpts.Scope == nil || authOpts.Scope.DomainName != "users" {
+		t.Fatalf("expected project to be scoped to the user domain, got %+v", authOpts.Scope)
+	}
+
+	_, err := newKeystoneClient(context.Background(), http.Client{}, authOpts, endpointOpts, "", 0)
+	th.AssertNoError(t, err)
+
+	auth := (*lastAuth)["auth"].(map[string]any)
+	project := auth["scope"].(map[string]any)["project"].(map[string]any)
+	if project["name"] != "baremetal" {
+		t.Errorf("expected project name baremetal, got %v", project["name"])
+	}
+	if domain := project["domain"].(map[string]any); domain["name"] != "users" {
+		t.Errorf("expected project domain users, got %v", domain["name"])
+	}
+}
+
+func TestKeystoneOptionsApplicationCredential(t *testing.T) {
+	server, lastAuth := newFakeKeystone(t)
+
+	data := IronicProviderModel{
+		AuthURL:                     types.StringValue(server.URL + "/identity/v3"),
+		ApplicationCredentialID:     types.StringValue("app-cred-id"),
+		ApplicationCredentialSecret: types.StringValue("app-cred-secret"),
+		ProjectName:                 types.StringValue("ignored"),
+	}
+
+	authOpts, endpointOpts := keystoneOptions(data)
+	if authOpts.Scope != nil {
+		t.Errorf("application credentials must not be scoped, got %+v", authOpts.Scope)
+	}
+	if endpointOpts.Availability != gophercloud.AvailabilityPublic {
+		t.Errorf("expected public availability by default, got %s", endpointOpts.Availability)
+	}
+
+	_, err := newKeystoneClient(context.Background(), http.Client{}, authOpts, endpointOpts, "", 0)
+	th.AssertNoError(t, err)
+
+	identity := (*lastAuth)["auth"].(map[string]any)["identity"].(map[string]any)
+	appCred := identity["application_credential"].(map[string]any)
+	if appCred["id"] != "app-cred-id" || appCred["secret"] != "app-cred-secret" {
+		t.Errorf("unexpected application credential payload: %v", appCred)
+	}
+}
+
+// testPKI is a throwaway CA along with a server and a client certificate
+// signed by it, written to PEM files.
+type testPKI struct {
+	caPool     *x509.CertPool
+	serverCert tls.Certificate
+	caFile     string
+	certFile   string
+	keyFile    string
+}
+
+func newTestPKI(t *testing.T) *testPKI {
+	t.Helper()
+	dir := t.TempDir()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	th.AssertNoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	th.AssertNoError(t, err)
+	caCert, err := x509.ParseCertificate(caDER)
+	th.AssertNoError(t, err)
+
+	issue := func(serial int64, usage x509.ExtKeyUsage) ([]byte, []byte) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		th.AssertNoError(t, err)
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: "localhost"},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+			IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+		th.AssertNoError(t, err)
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		th.AssertNoError(t, err)
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+			pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	}
+
+	pki := &testPKI{
+		caPool:   x509.NewCertPool(),
+		caFile:   filepath.Join(dir, "ca.crt"),
+		certFile: filepath.Join(dir, "client.crt"),
+		keyFile:  filepath.Join(dir, "client.key"),
+	}
+	pki.caPool.AddCert(caCert)
+
+	serverCertPEM, serverKeyPEM := issue(2, x509.ExtKeyUsageServerAuth)
+	pki.serverCert, err = tls.X509KeyPair(serverCertPEM, serverKeyPEM)
+	th.AssertNoError(t, err)
+
+	clientCertPEM, clientKeyPEM := issue(3, x509.ExtKeyUsageClientAuth)
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})
+	th.AssertNoError(t, os.WriteFile(pki.caFile, caPEM, 0o600))
+	th.AssertNoError(t, os.WriteFile(pki.certFile, clientCertPEM, 0o600))
+	th.AssertNoError(t, os.WriteFile(pki.keyFile, clientKeyPEM, 0o600))
+
+	return pki
+}
+
+// newTLSIronic starts an httptest TLS server answering the API versions
+// request, optionally requiring a client certificate signed by the test CA.
+func newTLSIronic(t *testing.T, pki *testPKI, requireClientCert bool) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"versions": [{"id": "v1", "status": "CURRENT", "version": "1.99"}]}`)
+	}))
+	server.TLS = &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{pki.serverCert},
+	}
+	if requireClientCert {
+		server.TLS.ClientAuth = tls.RequireAndVerifyClientCert
+		server.TLS.ClientCAs = pki.caPool
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestNewHTTPClientTLS(t *testing.T) {
+	pki := newTestPKI(t)
+	server := newTLSIronic(t, pki, false)
+	mtlsServer := newTLSIronic(t, pki, true)
+
+	tests := []struct {
+		name     string
+		server   *httptest.Server
+		opts     clientTLSOptions
+		expected string
+	}{
+		{
+			name:     "untrusted CA",
+			server:   server,
+			expected: "certificate signed by unknown authority",
+		},
+		{
+			name:   "custom CA bundle",
+			server: server,
+			opts:   clientTLSOptions{CACertFile: pki.caFile},
+		},
+		{
+			name:   "insecure",
+			server: server,
+			opts:   clientTLSOptions{Insecure: true},
+		},
+		{
+			name:     "missing client certificate",
+			server:   mtlsServer,
+			opts:     clientTLSOptions{CACertFile: pki.caFile},
+			expected: "certificate",
+		},
+		{
+			name:   "client certificate",
+			server: mtlsServer,
+			opts: clientTLSOptions{
+				CACertFile: pki.caFile,
+				CertFile:   pki.certFile,
+				KeyFile:    pki.keyFile,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			httpClient, err := newHTTPClient(test.opts)
+			th.AssertNoError(t, err)
+
+			client, err := noauth.NewBareMetalNoAuth(noauth.EndpointOpts{
+				IronicEndpoint: test.server.URL + "/v1",
+			})
+			th.AssertNoError(t, err)
+			client.HTTPClient = httpClient
+
+			err = healthCheck(context.Background(), client)
+			if test.expected == "" {
+				th.AssertNoError(t, err)
+			} else {
+				th.AssertError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewHTTPClientInvalidFiles(t *testing.T) {
+	pki := newTestPKI(t)
+
+	_, err := newHTTPClient(clientTLSOptions{CACertFile: filepath.Join(t.TempDir(), "missing.crt")})
+	th.AssertError(t, err, "failed to read CA bundle")
+
+	_, err = newHTTPClient(clientTLSOptions{CACertFile: pki.keyFile})
+	th.AssertError(t, err, "no certificates found")
+
+	_, err = newHTTPClient(clientTLSOptions{CertFile: pki.certFile})
+	th.AssertError(t, err, "both a client certificate and key are required")
+}
+
+func TestTLSOptionsEnvFallback(t *testing.T) {
+	t.Setenv("OS_CACERT", "/from/os/ca.crt")
+	t.Setenv("IRONIC_CERT_FILE", "/from/ironic/client.crt")
+	t.Setenv("OS_INSECURE", "true")
+
+	opts, err := tlsOptions(IronicProviderModel{
+		KeyFile: types.StringValue("/from/config/client.key"),
+	})
+	th.AssertNoError(t, err)
+	expected := clientTLSOptions{
+		CACertFile: "/from/os/ca.crt",
+		CertFile:   "/from/ironic/client.crt",
+		KeyFile:    "/from/config/client.key",
+		Insecure:   true,
+	}
+	if opts != expected {
+		t.Errorf("tlsOptions() = %+v, expected %+v", opts, expected)
+	}
+
+	opts, err = tlsOptions(IronicProviderModel{Insecure: types.BoolValue(false)})
+	th.AssertNoError(t, err)
+	if opts.Insecure {
+		t.Errorf("insecure = false in the configuration must win over the environment")
+	}
+
+	t.Setenv("OS_INSECURE", "maybe")
+	_, err = tlsOptions(IronicProviderModel{})
+	th.AssertError(t, err, "invalid value")
+}
+
+// newFlakyIronic serves the API versions document after failing the first
+// failures requests with the given status code.
+func newFlakyIronic(t *testing.T, failures int32, status int) (*gophercloud.ServiceClient, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if requests.Add(1) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"versions": [{"id": "v1", "status": "CURRENT", "version": "1.99"}]}`)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := noauth.NewBareMetalNoAuth(noauth.EndpointOpts{
+		IronicEndpoint: server.URL + "/v1",
+	})
+	th.AssertNoError(t, err)
+
+	return client, &requests
+}
+
+func TestWaitForAPI(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int32
+		status   int
+		timeout  time.Duration
+		requests int32
+		expected string
+	}{
+		{
+			name:     "available",
+			timeout:  time.Minute,
+			requests: 1,
+		},
+		{
+			name:     "becomes available",
+			failures: 2,
+			status:   http.StatusServiceUnavailable,
+			timeout:  time.Minute,
+			requests: 3,
+		},
+		{
+			name:     "no timeout",
+			failures: 1,
+			status:   http.StatusServiceUnavailable,
+			requests: 1,
+			expected: "503",
+		},
+		{
+			name:     "unauthorized",
+			failures: 100,
+			status:   http.StatusUnauthorized,
+			timeout:  time.Minute,
+			requests: 1,
+			expected: "401",
+		},
+		{
+			name:     "forbidden",
+			failures: 100,
+			status:   http.StatusForbidden,
+			timeout:  time.Minute,
+			requests: 1,
+			expected: "403",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client, requests := newFlakyIronic(t, test.failures, test.status)
+
+			err := waitForAPI(context.Background(), client, test.timeout)
+			if test.expected == "" {
+				th.AssertNoError(t, err)
+			} else {
+				th.AssertError(t, err, test.expected)
+			}
+			if got := requests.Load(); got != test.requests {
+				t.Errorf("expected %d requests, got %d", test.requests, got)
+			}
+		})
+	}
+}
+
+func TestWaitForAPITimeout(t *testing.T) {
+	client, requests := newFlakyIronic(t, 1000, http.StatusServiceUnavailable)
+
+	err := waitForAPI(context.Background(), client, 2*time.Second)
+	th.AssertError(t, err, "503")
+	if requests.Load() < 2 {
+		t.Errorf("expected the health check to be retried, got %d requests", requests.Load())
+	}
+}
+
+func TestWaitForAPINotCurrent(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"versions": [{"id": "v1", "status": "SUPPORTED", "version": "1.99"}]}`)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := noauth.NewBareMetalNoAuth(noauth.EndpointOpts{
+		IronicEndpoint: server.URL + "/v1",
+	})
+	th.AssertNoError(t, err)
+
+	err = waitForAPI(context.Background(), client, time.Minute)
+	th.AssertError(t, err, "not current")
+	if got := requests.Load(); got != 1 {
+		t.Errorf("expected 1 request, got %d", got)
+	}
+}
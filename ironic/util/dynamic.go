@@ -3,6 +3,7 @@ package util
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"reflect"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -56,6 +57,38 @@ func DynamicToMap(ctx context.Context, dynamic types.Dynamic) (map[string]any, e
 	return convertTerraformValueToGoValue(underlyingValue)
 }
 
+// SliceToDynamic converts a []any to types.Dynamic using deep reflection.
+func SliceToDynamic(ctx context.Context, input []any) (types.Dynamic, error) {
+	if input == nil {
+		return types.DynamicNull(), nil
+	}
+
+	_, value, err := convertGoValueToTerraformValue(input)
+	if err != nil {
+		return types.DynamicNull(), err
+	}
+
+	return types.DynamicValue(value), nil
+}
+
+// DynamicToSlice converts a types.Dynamic holding a list or tuple to []any.
+func DynamicToSlice(ctx context.Context, dynamic types.Dynamic) ([]any, error) {
+	if dynamic.IsNull() || dynamic.IsUnknown() {
+		return nil, nil
+	}
+
+	goValue, err := convertSingleTerraformValueToGo(dynamic.UnderlyingValue())
+	if err != nil {
+		return nil, err
+	}
+
+	result, ok := goValue.([]any)
+	if !ok {
+		return nil, fmt.Errorf("expected a list, got %T", goValue)
+	}
+	return result, nil
+}
+
 // convertGoValueToTerraformValue converts a Go value to Terraform attr.Type and attr.Value.
 func convertGoValueToTerraformValue(value any) (attr.Type, attr.Value, error) {
 	if value == nil {
@@ -216,6 +249,13 @@ func convertSingleTerraformValueToGo(value attr.Value) (any, error) {
 		return v.ValueInt64(), nil
 	case basetypes.Float64Value:
 		return v.ValueFloat64(), nil
+	case basetypes.NumberValue:
+		// Numbers coming from the configuration are arbitrary precision
+		if i, accuracy := v.ValueBigFloat().Int64(); accuracy == big.Exact {
+			return i, nil
+		}
+		f, _ := v.ValueBigFloat().Float64()
+		return f, nil
 	case basetypes.ListValue:
 		elements := v.Elements()
 		result := make([]any, len(elements))
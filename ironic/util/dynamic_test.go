@@ -2,7 +2,11 @@ package util
 
 import (
 	"context"
+	"math/big"
 	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 func TestMapToDynamicAndBack(t *testing.T) {
@@ -795,3 +799,61 @@ func TestIronicLikeStructures(t *testing.T) {
 		}
 	}
 }
+
+func TestSliceToDynamicAndBack(t *testing.T) {
+	ctx := context.Background()
+
+	original := []any{
+		map[string]any{"op": "ge", "field": "memory_mb", "value": 4096},
+		map[string]any{"op": "eq", "field": "cpu_arch", "value": "x86_64"},
+	}
+
+	dynamic, err := SliceToDynamic(ctx, original)
+	if err != nil {
+		t.Fatalf("Error converting slice to dynamic: %v", err)
+	}
+
+	result, err := DynamicToSlice(ctx, dynamic)
+	if err != nil {
+		t.Fatalf("Error converting dynamic to slice: %v", err)
+	}
+
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 elements, got %d", len(result))
+	}
+	first, ok := result[0].(map[string]any)
+	if !ok {
+		t.Fatalf("Expected map[string]any, got %T", result[0])
+	}
+	if first["value"] != int64(4096) {
+		t.Errorf("Expected value=4096, got %v", first["value"])
+	}
+
+	if _, err := DynamicToSlice(ctx, types.DynamicValue(types.StringValue("nope"))); err == nil {
+		t.Error("Expected an error for a non-list dynamic value")
+	}
+}
+
+func TestDynamicNumbers(t *testing.T) {
+	ctx := context.Background()
+
+	// Numbers in the configuration arrive as arbitrary precision values.
+	object := types.ObjectValueMust(
+		map[string]attr.Type{"port": types.NumberType, "ratio": types.NumberType},
+		map[string]attr.Value{
+			"port":  types.NumberValue(big.NewFloat(623)),
+			"ratio": types.NumberValue(big.NewFloat(0.5)),
+		},
+	)
+
+	result, err := DynamicToMap(ctx, types.DynamicValue(object))
+	if err != nil {
+		t.Fatalf("Error converting dynamic to map: %v", err)
+	}
+	if result["port"] != int64(623) {
+		t.Errorf("Expected port=623, got %v (%T)", result["port"], result["port"])
+	}
+	if result["ratio"] != 0.5 {
+		t.Errorf("Expected ratio=0.5, got %v (%T)", result["ratio"], result["ratio"])
+	}
+}
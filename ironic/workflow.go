@@ -2,7 +2,9 @@ package ironic
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"slices"
 	"time"
 
@@ -12,6 +14,46 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+const (
+	// defaultProvisionTimeout bounds provision state changes when the
+	// context carries no deadline of its own.
+	defaultProvisionTimeout = 30 * time.Minute
+)
+
+var (
+	// workflowPollInterval is how often the provision workflow checks on
+	// the node.
+	workflowPollInterval = 15 * time.Second
+	// provisionStatePollInterval is how often WaitForTargetProvisionState
+	// checks on the node.
+	provisionStatePollInterval = 10 * time.Second
+)
+
+// withDefaultTimeout bounds ctx by timeout unless it already has a deadline,
+// such as one set from a resource's timeouts block. It also returns the
+// effective timeout, for error messages.
+func withDefaultTimeout(
+	ctx context.Context,
+	timeout time.Duration,
+) (context.Context, context.CancelFunc, time.Duration) {
+	if deadline, ok := ctx.Deadline(); ok {
+		ctx, cancel := context.WithCancel(ctx)
+		return ctx, cancel, time.Until(deadline).Round(time.Second)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return ctx, cancel, timeout
+}
+
+// operationContext bounds ctx by the timeout set in a resource's timeouts
+// block for the whole operation. Without one, a zero timeout, every provision
+// state change waits up to defaultProvisionTimeout on its own.
+func operationContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
 // StateTransition represents a valid state transition.
 type StateTransition struct {
 	From   nodes.ProvisionState
@@ -75,6 +117,7 @@ var stateTransitions = []StateTransition{
 
 	// From rescued
 	{nodes.Rescue, nodes.TargetUnrescue, nodes.Unrescuing},
+	{nodes.Rescue, nodes.TargetDeleted, nodes.Deleting},
 
 	// From unrescuing
 	{nodes.Unrescuing, nodes.TargetActive, nodes.Active}, // success
@@ -186,7 +229,46 @@ func isTransientState(state nodes.ProvisionState) bool {
 	return slices.Contains(transientStates, state)
 }
 
-// ChangeProvisionStateToTarget triggers a provision state change on a node.
+// manualCleanStates are the provision states from which runManualClean can
+// clean a node.
+var manualCleanStates = []nodes.ProvisionState{
+	nodes.Enroll,
+	nodes.Manageable,
+	nodes.Available,
+}
+
+// runManualClean runs the clean steps on a node that is enroll, manageable or
+// available. Cleaning leaves the node manageable, so an available node is
+// provided again afterwards.
+func runManualClean(
+	ctx context.Context,
+	client *gophercloud.ServiceClient,
+	nodeID string,
+	cleanSteps []nodes.CleanStep,
+) error {
+	node, err := nodes.Get(ctx, client, nodeID).Extract()
+	if err != nil {
+		return fmt.Errorf("could not get node: %w", err)
+	}
+
+	state := nodes.ProvisionState(node.ProvisionState)
+	if !slices.Contains(manualCleanStates, state) {
+		return fmt.Errorf("manual cleaning needs the node to be enroll, manageable or available, but it is %s", state)
+	}
+
+	err = ChangeProvisionStateToTarget(ctx, client, nodeID, nodes.TargetClean, nil, nil, cleanSteps, nil)
+	if err != nil {
+		return err
+	}
+
+	if state == nodes.Available {
+		return ChangeProvisionStateToTarget(ctx, client, nodeID, nodes.TargetProvide, nil, nil, nil, nil)
+	}
+	return nil
+}
+
+// ChangeProvisionStateToTarget drives a node to the target provision state,
+// for at most 30 minutes unless the context has an earlier deadline.
 func ChangeProvisionStateToTarget(
 	ctx context.Context,
 	client *gophercloud.ServiceClient,
@@ -197,24 +279,40 @@ func ChangeProvisionStateToTarget(
 	cleanSteps []nodes.CleanStep,
 	serviceSteps []nodes.ServiceStep,
 ) error {
+	return runProvisionWorkflow(&provisionWorkflow{
+		ctx:          ctx,
+		client:       client,
+		nodeID:       nodeID,
+		target:       target,
+		configDrive:  configDrive,
+		deploySteps:  deploySteps,
+		cleanSteps:   cleanSteps,
+		serviceSteps: serviceSteps,
+	})
+}
+
+// runProvisionWorkflow drives a node to the target of the workflow, unless it
+// is already there. Callers needing options that ChangeProvisionStateToTarget
+// does not take build the workflow themselves.
+func runProvisionWorkflow(w *provisionWorkflow) error {
 	// Get current node state
-	node, err := nodes.Get(ctx, client, nodeID).Extract()
+	node, err := nodes.Get(w.ctx, w.client, w.nodeID).Extract()
 	if err != nil {
-		return fmt.Errorf("failed to get node %s: %w", nodeID, err)
+		return fmt.Errorf("failed to get node %s: %w", w.nodeID, err)
 	}
 
 	currentState := nodes.ProvisionState(node.ProvisionState)
-	tflog.Info(ctx, "Starting provision state change", map[string]any{
-		"node_id":       nodeID,
+	tflog.Info(w.ctx, "Starting provision state change", map[string]any{
+		"node_id":       w.nodeID,
 		"current_state": string(currentState),
-		"target":        string(target),
+		"target":        string(w.target),
 	})
 
 	// Check if we're already in the desired final state
-	if expectedState, ok := getExpectedState(currentState, target); ok {
+	if expectedState, ok := getExpectedState(currentState, w.target); ok {
 		if currentState == expectedState {
-			tflog.Info(ctx, "Node already in target state", map[string]any{
-				"node_id": nodeID,
+			tflog.Info(w.ctx, "Node already in target state", map[string]any{
+				"node_id": w.nodeID,
 				"state":   string(currentState),
 			})
 			return nil
@@ -222,18 +320,7 @@ func ChangeProvisionStateToTarget(
 	}
 
 	// Perform the state change workflow
-	workflow := &provisionWorkflow{
-		ctx:          ctx,
-		client:       client,
-		nodeID:       nodeID,
-		target:       target,
-		configDrive:  configDrive,
-		deploySteps:  deploySteps,
-		cleanSteps:   cleanSteps,
-		serviceSteps: serviceSteps,
-	}
-
-	return workflow.execute()
+	return w.execute()
 }
 
 // provisionWorkflow manages the state machine execution.
@@ -246,28 +333,35 @@ type provisionWorkflow struct {
 	deploySteps  []nodes.DeployStep
 	cleanSteps   []nodes.CleanStep
 	serviceSteps []nodes.ServiceStep
+	// disableRamdisk runs clean or service steps without booting the
+	// agent ramdisk.
+	disableRamdisk bool
+	// rescuePassword is the password of the rescue user, required to
+	// rescue a node.
+	rescuePassword string
+	// requested is set once the target itself has been requested, as some
+	// targets end in the state they start from.
+	requested bool
 }
 
-// execute runs the provision workflow.
+// execute runs the provision workflow until the node reaches the target or
+// the context deadline expires.
 func (w *provisionWorkflow) execute() error {
-	const (
-		maxAttempts  = 1000
-		pollInterval = 15 * time.Second
-		maxTimeout   = 30 * time.Minute
-	)
+	ctx, cancel, timeout := withDefaultTimeout(w.ctx, defaultProvisionTimeout)
+	defer cancel()
+	w.ctx = ctx
 
-	timeout := time.After(maxTimeout)
-	ticker := time.NewTicker(pollInterval)
+	ticker := time.NewTicker(workflowPollInterval)
 	defer ticker.Stop()
 
-	for attempt := 1; attempt <= maxAttempts; attempt++ {
+	for attempt := 1; ; attempt++ {
 		select {
 		case <-w.ctx.Done():
+			if errors.Is(w.ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("timeout waiting for provision state change after %v", timeout)
+			}
 			return fmt.Errorf("context cancelled: %w", w.ctx.Err())
 
-		case <-timeout:
-			return fmt.Errorf("timeout waiting for provision state change after %v", maxTimeout)
-
 		case <-ticker.C:
 			// Get current node state
 			node, err := nodes.Get(w.ctx, w.client, w.nodeID).Extract()
@@ -289,6 +383,9 @@ func (w *provisionWorkflow) execute() error {
 
 			// Check if we've reached the final desired state
 			if done, err := w.checkCompletion(currentState); done {
+				if err != nil && node.LastError != "" {
+					return fmt.Errorf("%w: %s", err, node.LastError)
+				}
 				return err
 			}
 
@@ -301,6 +398,9 @@ func (w *provisionWorkflow) execute() error {
 						err,
 					)
 				}
+				if isRequestRejected(err) {
+					return err
+				}
 				tflog.Warn(w.ctx, "Action failed, will retry", map[string]any{
 					"node_id": w.nodeID,
 					"error":   err.Error(),
@@ -308,8 +408,19 @@ func (w *provisionWorkflow) execute() error {
 			}
 		}
 	}
+}
 
-	return fmt.Errorf("workflow failed after %d attempts", maxAttempts)
+// isRequestRejected reports whether Ironic rejected a request with a client
+// error, retrying the same request cannot succeed. Conflicts are not
+// included, they usually mean the node is locked by another operation.
+func isRequestRejected(err error) bool {
+	var codeError gophercloud.ErrUnexpectedResponseCode
+	if !errors.As(err, &codeError) {
+		return false
+	}
+	return codeError.Actual >= http.StatusBadRequest &&
+		codeError.Actual < http.StatusInternalServerError &&
+		codeError.Actual != http.StatusConflict
 }
 
 // checkCompletion determines if the workflow is complete.
@@ -324,13 +435,15 @@ func (w *provisionWorkflow) checkCompletion(currentState nodes.ProvisionState) (
 	case nodes.TargetManage:
 		return currentState == nodes.Manageable, nil
 	case nodes.TargetInspect:
-		return currentState == nodes.Manageable, nil // Inspection ends in manageable
+		return w.requested && currentState == nodes.Manageable, nil // Inspection ends in manageable
 	case nodes.TargetClean:
-		return currentState == nodes.Available, nil // Cleaning ends in available
+		return w.requested && currentState == nodes.Manageable, nil // Manual cleaning ends in manageable
 	case nodes.TargetProvide:
 		return currentState == nodes.Available, nil
 	case nodes.TargetActive:
 		return currentState == nodes.Active, nil
+	case nodes.TargetRebuild:
+		return w.requested && currentState == nodes.Active, nil // Rebuilding starts from active
 	case nodes.TargetDeleted:
 		return currentState == nodes.Available || currentState == nodes.Manageable, nil
 	case nodes.TargetRescue:
@@ -340,7 +453,7 @@ func (w *provisionWorkflow) checkCompletion(currentState nodes.ProvisionState) (
 	case nodes.TargetAdopt:
 		return currentState == nodes.Manageable, nil
 	case nodes.TargetService:
-		return currentState == nodes.Active, nil
+		return w.requested && currentState == nodes.Active, nil // Servicing starts from active
 	case nodes.TargetUnhold:
 		// Handle unhold completion based on previous state
 		// This is complex and needs state-specific logic
@@ -427,7 +540,7 @@ func (w *provisionWorkflow) determineNextTarget(
 		}
 
 	case nodes.TargetClean:
-		if currentState == nodes.Enroll {
+		if currentState == nodes.Enroll || currentState == nodes.Available {
 			return nodes.TargetManage
 		}
 		if currentState == nodes.Manageable {
@@ -455,15 +568,37 @@ func (w *provisionWorkflow) determineNextTarget(
 	return ""
 }
 
+// provisionStateOpts adds the provision state change fields gophercloud does
+// not know about.
+type provisionStateOpts struct {
+	nodes.ProvisionStateOpts
+	// DisableRamdisk requires microversion 1.70.
+	DisableRamdisk bool
+}
+
+// ToProvisionStateMap assembles the request body.
+func (opts provisionStateOpts) ToProvisionStateMap() (map[string]any, error) {
+	body, err := opts.ProvisionStateOpts.ToProvisionStateMap()
+	if err != nil {
+		return nil, err
+	}
+	if opts.DisableRamdisk {
+		body["disable_ramdisk"] = true
+	}
+	return body, nil
+}
+
 // changeProvisionState executes a provision state change.
 func (w *provisionWorkflow) changeProvisionState(target nodes.TargetProvisionState) error {
-	opts := nodes.ProvisionStateOpts{
-		Target: target,
+	opts := provisionStateOpts{
+		ProvisionStateOpts: nodes.ProvisionStateOpts{
+			Target: target,
+		},
 	}
 
 	// Add additional options based on target
 	switch target {
-	case nodes.TargetActive:
+	case nodes.TargetActive, nodes.TargetRebuild:
 		opts.ConfigDrive = w.configDrive
 		if w.deploySteps != nil {
 			opts.DeploySteps = w.deploySteps
@@ -474,12 +609,16 @@ func (w *provisionWorkflow) changeProvisionState(target nodes.TargetProvisionSta
 		} else {
 			opts.CleanSteps = []nodes.CleanStep{}
 		}
+		opts.DisableRamdisk = w.disableRamdisk
 	case nodes.TargetService:
 		if w.serviceSteps != nil {
 			opts.ServiceSteps = w.serviceSteps
 		} else {
 			opts.ServiceSteps = []nodes.ServiceStep{}
 		}
+		opts.DisableRamdisk = w.disableRamdisk
+	case nodes.TargetRescue:
+		opts.RescuePassword = w.rescuePassword
 	case nodes.TargetAbort:
 		// No additional options typically needed
 	case nodes.TargetUnhold:
@@ -495,42 +634,43 @@ func (w *provisionWorkflow) changeProvisionState(target nodes.TargetProvisionSta
 	if err != nil {
 		return fmt.Errorf("failed to change provision state to '%s': %w", target, err)
 	}
+	if target == w.target {
+		w.requested = true
+	}
 
 	return nil
 }
 
-// WaitForTargetProvisionState waits for a node to reach a specific state.
+// WaitForTargetProvisionState waits for a node to reach a specific state,
+// for at most 30 minutes unless the context has an earlier deadline.
 func WaitForTargetProvisionState(
 	ctx context.Context,
 	client *gophercloud.ServiceClient,
 	nodeID string,
 	targetState nodes.ProvisionState,
 ) error {
-	const (
-		pollInterval = 10 * time.Second
-		maxTimeout   = 30 * time.Minute
-	)
+	ctx, cancel, timeout := withDefaultTimeout(ctx, defaultProvisionTimeout)
+	defer cancel()
 
-	timeout := time.After(maxTimeout)
-	ticker := time.NewTicker(pollInterval)
+	ticker := time.NewTicker(provisionStatePollInterval)
 	defer ticker.Stop()
 
 	tflog.Debug(ctx, "Waiting for provision state", map[string]any{
 		"node_id":       nodeID,
 		"target_state":  string(targetState),
-		"poll_interval": pollInterval.String(),
-		"max_timeout":   maxTimeout.String(),
+		"poll_interval": provisionStatePollInterval.String(),
+		"max_timeout":   timeout.String(),
 	})
 
 	for {
 		select {
 		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("timeout waiting for node %s to reach state '%s' after %v",
+					nodeID, targetState, timeout)
+			}
 			return fmt.Errorf("context cancelled while waiting for provision state: %w", ctx.Err())
 
-		case <-timeout:
-			return fmt.Errorf("timeout waiting for node %s to reach state '%s' after %v",
-				nodeID, targetState, maxTimeout)
-
 		case <-ticker.C:
 			node, err := nodes.Get(ctx, client, nodeID).Extract()
 			if err != nil {
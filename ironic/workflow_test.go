@@ -1,9 +1,18 @@
 package ironic
 
 import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "net/http"
+    "net/http/httptest"
     "testing"
+    "time"
 
+    "github.com/gophercloud/gophercloud/v2"
+    "github.com/gophercloud/gophercloud/v2/openstack/baremetal/noauth"
     "github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+    th "github.com/metal3-community/terraform-provider-ironic/testhelper"
 )
 
 func TestIsTerminalFailureState(t *testing.T) {
@@ -154,3 +163,181 @@ func TestGetExpectedState(t *testing.T) {
         }
     }
 }
+
+// newStaticNodeIronic returns a client for a fake Ironic where the node stays in
+// the given provision state.
+func newStaticNodeIronic(t *testing.T, state nodes.ProvisionState) *gophercloud.ServiceClient {
+    t.Helper()
+
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        w.Header().Set("Content-Type", "application/json")
+        fmt.Fprintf(w, `{"uuid": "node", "provision_state": %q}`, state)
+    }))
+    t.Cleanup(server.Close)
+
+    client, err := noauth.NewBareMetalNoAuth(noauth.EndpointOpts{
+        IronicEndpoint: server.URL + "/v1",
+    })
+    th.AssertNoError(t, err)
+
+    return client
+}
+
+func TestProvisionWorkflowTimeout(t *testing.T) {
+    defer func(interval time.Duration) { workflowPollInterval = interval }(workflowPollInterval)
+    workflowPollInterval = 10 * time.Millisecond
+
+    client := newStaticNodeIronic(t, nodes.Deploying)
+
+    ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+    defer cancel()
+
+    err := ChangeProvisionStateToTarget(ctx, client, "node", nodes.TargetActive, nil, nil, nil, nil)
+    th.AssertError(t, err, "timeout waiting for provision state change")
+
+    // Without a deadline of its own, reaching the target is not limited
+    client = newStaticNodeIronic(t, nodes.Active)
+    err = (&provisionWorkflow{ctx: context.Background(), client: client, nodeID: "node", target: nodes.TargetActive}).execute()
+    th.AssertNoError(t, err)
+}
+
+func TestOperationContext(t *testing.T) {
+    // No timeouts block leaves every state change its own default
+    ctx, cancel := operationContext(context.Background(), 0)
+    defer cancel()
+    if _, ok := ctx.Deadline(); ok {
+        t.Error("expected no deadline without a configured timeout")
+    }
+
+    ctx, cancel = operationContext(context.Background(), time.Hour)
+    defer cancel()
+    deadline, ok := ctx.Deadline()
+    if !ok || time.Until(deadline) > time.Hour {
+        t.Errorf("expected a deadline within an hour, got %v", deadline)
+    }
+}
+
+func TestWaitForTargetProvisionStateTimeout(t *testing.T) {
+    defer func(interval time.Duration) { provisionStatePollInterval = interval }(provisionStatePollInterval)
+    provisionStatePollInterval = 10 * time.Millisecond
+
+    client := newStaticNodeIronic(t, nodes.Cleaning)
+
+    ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+    defer cancel()
+
+    err := WaitForTargetProvisionState(ctx, client, "node", nodes.Available)
+    th.AssertError(t, err, "timeout waiting for node node to reach state 'available'")
+
+    ctx, cancel = context.WithCancel(context.Background())
+    cancel()
+    err = WaitForTargetProvisionState(ctx, client, "node", nodes.Available)
+    th.AssertError(t, err, "context cancelled")
+}
+
+func TestProvisionWorkflowManualClean(t *testing.T) {
+    defer func(interval time.Duration) { workflowPollInterval = interval }(workflowPollInterval)
+    workflowPollInterval = 10 * time.Millisecond
+
+    state := nodes.Available
+    var targets []string
+
+    mux := http.NewServeMux()
+    mux.HandleFunc("/v1/nodes/node/states/provision", func(w http.ResponseWriter, r *http.Request) {
+        var body map[string]any
+        _ = json.NewDecoder(r.Body).Decode(&body)
+        targets = append(targets, body["target"].(string))
+        switch body["target"] {
+        case "manage":
+            state = nodes.Manageable
+        case "clean":
+            state = nodes.Cleaning
+        }
+        w.WriteHeader(http.StatusAccepted)
+    })
+    mux.HandleFunc("/v1/nodes/node", func(w http.ResponseWriter, r *http.Request) {
+        w.Header().Set("Content-Type", "application/json")
+        fmt.Fprintf(w, `{"uuid": "node", "provision_state": %q}`, state)
+        // Cleaning finishes after being seen once
+        if state == nodes.Cleaning {
+            state = nodes.Manageable
+        }
+    })
+    server := httptest.NewServer(mux)
+    defer server.Close()
+
+    client, err := noauth.NewBareMetalNoAuth(noauth.EndpointOpts{
+        IronicEndpoint: server.URL + "/v1",
+    })
+    th.AssertNoError(t, err)
+
+    cleanSteps := []nodes.CleanStep{{Interface: nodes.InterfaceBIOS, Step: "apply_configuration"}}
+    err = ChangeProvisionStateToTarget(context.Background(), client, "node", nodes.TargetClean, nil, nil, cleanSteps, nil)
+    th.AssertNoError(t, err)
+
+    if len(targets) != 2 || targets[0] != "manage" || targets[1] != "clean" {
+        t.Errorf("expected to manage then clean the node once, got %v", targets)
+    }
+}
+
+func TestProvisionWorkflowRescue(t *testing.T) {
+    defer func(interval time.Duration) { workflowPollInterval = interval }(workflowPollInterval)
+    workflowPollInterval = 10 * time.Millisecond
+
+    state := nodes.Active
+    var requests []map[string]any
+
+    mux := http.NewServeMux()
+    mux.HandleFunc("/v1/nodes/node/states/provision", func(w http.ResponseWriter, r *http.Request) {
+        var body map[string]any
+        _ = json.NewDecoder(r.Body).Decode(&body)
+        requests = append(requests, body)
+        switch body["target"] {
+        case "rescue":
+            state = nodes.Rescuing
+        case "unrescue":
+            state = nodes.Unrescuing
+        }
+        w.WriteHeader(http.StatusAccepted)
+    })
+    mux.HandleFunc("/v1/nodes/node", func(w http.ResponseWriter, r *http.Request) {
+        w.Header().Set("Content-Type", "application/json")
+        fmt.Fprintf(w, `{"uuid": "node", "provision_state": %q}`, state)
+        // Transitions finish after being seen once
+        switch state {
+        case nodes.Rescuing:
+            state = nodes.Rescue
+        case nodes.Unrescuing:
+            state = nodes.Active
+        }
+    })
+    server := httptest.NewServer(mux)
+    defer server.Close()
+
+    client, err := noauth.NewBareMetalNoAuth(noauth.EndpointOpts{
+        IronicEndpoint: server.URL + "/v1",
+    })
+    th.AssertNoError(t, err)
+
+    err = runProvisionWorkflow(&provisionWorkflow{
+        ctx:            context.Background(),
+        client:         client,
+        nodeID:         "node",
+        target:         nodes.TargetRescue,
+        rescuePassword: "s3cret",
+    })
+    th.AssertNoError(t, err)
+    if state != nodes.Rescue {
+        t.Errorf("expected node to be rescued, got %s", state)
+    }
+
+    err = ChangeProvisionStateToTarget(context.Background(), client, "node", nodes.TargetUnrescue, nil, nil, nil, nil)
+    th.AssertNoError(t, err)
+    if state != nodes.Active {
+        t.Errorf("expected node to be active, got %s", state)
+    }
+
+    if len(requests) != 2 || requests[0]["rescue_password"] != "s3cret" || requests[1]["target"] != "unrescue" {
+        t.Errorf("unexpected provision state requests: %v", requests)
+    }
+}